@@ -0,0 +1,191 @@
+package pager
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"time"
+)
+
+// CursorError is returned when a cursor token can't be decoded.
+type CursorError string
+
+// Error returns the error message.
+func (e CursorError) Error() string {
+	return string(e)
+}
+
+const (
+	// ErrCursorMalformed is returned for tokens that are no cursor tokens at all.
+	ErrCursorMalformed = CursorError("pager: malformed cursor")
+
+	// ErrCursorTampered is returned for tokens with an invalid signature.
+	ErrCursorTampered = CursorError("pager: invalid cursor signature")
+
+	// ErrCursorExpired is returned for tokens that are expired.
+	ErrCursorExpired = CursorError("pager: cursor expired")
+
+	// ErrCursorNoKey is returned by a codec without a key, since its tokens could be forged.
+	ErrCursorNoKey = CursorError("pager: cursor codec without key")
+
+	// ErrCursorInvalid is returned for cursors with a negative offset or a height below 1,
+	// since their tokens couldn't be decoded.
+	ErrCursorInvalid = CursorError("pager: invalid cursor")
+)
+
+const (
+	cursorVersion = 1
+	cursorMACLen  = 16
+)
+
+// Cursor is the position that is stored within a cursor token.
+type Cursor struct {
+	// Offset is the index of the first item of the page.
+	Offset int
+
+	// Height is the number of items per page.
+	Height int
+
+	// Key is the key of the last seen item for keyset pagination.
+	// It is empty for offset based cursors.
+	Key string
+
+	// Expires is the time when the token expires.
+	// It is the zero time for tokens without expiry.
+	Expires time.Time
+}
+
+// CursorCodec encodes cursors into opaque tokens and decodes them.
+// The tokens are URL safe base64 strings that are signed with a HMAC-SHA256
+// of the given key, so that they can't be forged or modified by the client.
+type CursorCodec struct {
+	// Key is the secret key of the signature.
+	Key []byte
+
+	// TTL is the time a token is valid. Zero means no expiry.
+	TTL time.Duration
+
+	// Now returns the current time. If it is nil, time.Now is used.
+	Now func() time.Time
+}
+
+// Encode returns the token for the given cursor.
+// The Expires field of the cursor is set via the TTL of the codec.
+// The returned error is ErrCursorNoKey or ErrCursorInvalid.
+func (c CursorCodec) Encode(cur Cursor) (string, error) {
+	switch {
+	case len(c.Key) == 0:
+		return "", ErrCursorNoKey
+	case cur.Offset < 0 || cur.Height < 1:
+		return "", ErrCursorInvalid
+	}
+
+	var expires int64
+	if c.TTL > 0 {
+		expires = c.now().Add(c.TTL).Unix()
+	}
+
+	buf := make([]byte, 0, 1+3*binary.MaxVarintLen64+len(cur.Key)+cursorMACLen)
+	buf = append(buf, cursorVersion)
+	buf = binary.AppendUvarint(buf, uint64(cur.Offset))
+	buf = binary.AppendUvarint(buf, uint64(cur.Height))
+	buf = binary.AppendUvarint(buf, uint64(expires))
+	buf = append(buf, cur.Key...)
+	buf = append(buf, c.mac(buf)...)
+
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// EncodePager returns the token for the current page of the given pager.
+// If there is no data to be shown, the offset is the length of the data.
+// See Encode for the errors.
+func (c CursorCodec) EncodePager(pg Dispatcher) (string, error) {
+	s := pg.State()
+	from, _, _ := s.Indexes()
+	if from == -1 {
+		from = s.Len
+	}
+	return c.Encode(Cursor{Offset: from, Height: s.Height})
+}
+
+// EncodeKey returns the token for a keyset cursor, where key is the key
+// of the last seen item. height must be positive, see Encode.
+func (c CursorCodec) EncodeKey(key string, height int) (string, error) {
+	return c.Encode(Cursor{Key: key, Height: height})
+}
+
+// Decode returns the cursor of the given token.
+// The returned error is one of ErrCursorMalformed, ErrCursorTampered, ErrCursorExpired
+// and ErrCursorNoKey.
+func (c CursorCodec) Decode(token string) (cur Cursor, err error) {
+	if len(c.Key) == 0 {
+		return cur, ErrCursorNoKey
+	}
+
+	buf, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || len(buf) < 1+cursorMACLen {
+		return cur, ErrCursorMalformed
+	}
+
+	payload, sig := buf[:len(buf)-cursorMACLen], buf[len(buf)-cursorMACLen:]
+	if !hmac.Equal(sig, c.mac(payload)) {
+		return cur, ErrCursorTampered
+	}
+
+	if payload[0] != cursorVersion {
+		return cur, ErrCursorMalformed
+	}
+	payload = payload[1:]
+
+	var fields [3]uint64
+	for i := range fields {
+		var n int
+		fields[i], n = binary.Uvarint(payload)
+		if n <= 0 {
+			return cur, ErrCursorMalformed
+		}
+		payload = payload[n:]
+	}
+
+	const maxInt = int(^uint(0) >> 1)
+	if fields[0] > uint64(maxInt) || fields[1] == 0 || fields[1] > uint64(maxInt) {
+		return cur, ErrCursorMalformed
+	}
+
+	cur.Offset = int(fields[0])
+	cur.Height = int(fields[1])
+	cur.Key = string(payload)
+
+	if fields[2] > 0 {
+		cur.Expires = time.Unix(int64(fields[2]), 0)
+		if !c.now().Before(cur.Expires) {
+			return Cursor{}, ErrCursorExpired
+		}
+	}
+
+	return cur, nil
+}
+
+// Pager decodes the given token and returns a pager that is positioned at
+// the offset of the cursor. Like the pagers of Query, it uses the Top style.
+func (c CursorCodec) Pager(token string, dataLen int) (Dispatcher, error) {
+	cur, err := c.Decode(token)
+	if err != nil {
+		return nil, err
+	}
+	return New(cur.Height, dataLen, Top(), PreSelect(uint(cur.Offset))), nil
+}
+
+func (c CursorCodec) mac(payload []byte) []byte {
+	h := hmac.New(sha256.New, c.Key)
+	h.Write(payload)
+	return h.Sum(nil)[:cursorMACLen]
+}
+
+func (c CursorCodec) now() time.Time {
+	if c.Now == nil {
+		return time.Now()
+	}
+	return c.Now()
+}
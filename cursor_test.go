@@ -0,0 +1,127 @@
+package pager
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	codec := CursorCodec{Key: []byte("secret")}
+
+	tests := []Cursor{
+		{Offset: 0, Height: 10},
+		{Offset: 1234567, Height: 50},
+		{Height: 20, Key: "2018-01-02T15:04:05Z|4711"},
+	}
+
+	for _, test := range tests {
+		token, err := codec.Encode(test)
+		if err != nil {
+			t.Errorf("Encode(%#v); err = %v", test, err)
+			continue
+		}
+
+		got, err := codec.Decode(token)
+
+		if err != nil {
+			t.Errorf("Decode(Encode(%#v)); err = %v", test, err)
+			continue
+		}
+
+		if got != test {
+			t.Errorf("Decode(Encode(%#v)) = %#v", test, got)
+		}
+	}
+}
+
+func TestCursorPager(t *testing.T) {
+	codec := CursorCodec{Key: []byte("secret")}
+
+	pg := New(3, len(data), Top(), PreSelect(6))
+	token, err := codec.EncodePager(pg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pg2, err := codec.Pager(token, len(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lines, _ := displayData(pg2)
+
+	if got, want := len(lines), 3; got != want {
+		t.Fatalf("len(lines) = %v; want %v", got, want)
+	}
+
+	if got, want := lines[0], "seven"; got != want {
+		t.Errorf("lines[0] = %#v; want %#v", got, want)
+	}
+}
+
+func TestCursorErrors(t *testing.T) {
+	now := time.Date(2018, 1, 2, 15, 4, 5, 0, time.UTC)
+	codec := CursorCodec{Key: []byte("secret"), TTL: time.Hour, Now: func() time.Time { return now }}
+
+	token, err := codec.Encode(Cursor{Offset: 20, Height: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	raw, _ := base64.RawURLEncoding.DecodeString(token)
+	raw[1]++
+	tampered := base64.RawURLEncoding.EncodeToString(raw)
+
+	otherKey := CursorCodec{Key: []byte("other"), Now: codec.Now}
+
+	later := codec
+	later.Now = func() time.Time { return now.Add(2 * time.Hour) }
+
+	tests := []struct {
+		codec CursorCodec
+		token string
+		err   error
+	}{
+		{codec, token, nil},
+		{codec, "", ErrCursorMalformed},
+		{codec, "not base64!", ErrCursorMalformed},
+		{codec, "YWJj", ErrCursorMalformed},
+		{codec, tampered, ErrCursorTampered},
+		{otherKey, token, ErrCursorTampered},
+		{later, token, ErrCursorExpired},
+		{CursorCodec{}, token, ErrCursorNoKey},
+	}
+
+	for _, test := range tests {
+		_, err := test.codec.Decode(test.token)
+
+		if got, want := err, test.err; got != want {
+			t.Errorf("Decode(%q); err = %v; want %v", test.token, got, want)
+		}
+	}
+}
+
+func TestCursorEncodeErrors(t *testing.T) {
+	codec := CursorCodec{Key: []byte("secret")}
+
+	tests := []struct {
+		codec  CursorCodec
+		cursor Cursor
+		err    error
+	}{
+		{CursorCodec{}, Cursor{Height: 10}, ErrCursorNoKey},
+		{codec, Cursor{Height: 0}, ErrCursorInvalid},
+		{codec, Cursor{Offset: -1, Height: 10}, ErrCursorInvalid},
+	}
+
+	for _, test := range tests {
+		if _, err := test.codec.Encode(test.cursor); err != test.err {
+			t.Errorf("Encode(%+v) with key %q; err = %v; want %v", test.cursor, test.codec.Key, err, test.err)
+		}
+	}
+
+	if _, err := codec.EncodeKey("4711", 0); err != ErrCursorInvalid {
+		t.Errorf("EncodeKey(4711, 0); err = %v; want %v", err, ErrCursorInvalid)
+	}
+}
@@ -0,0 +1,124 @@
+package pager
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Line is a line of a document, see Document.
+type Line struct {
+	// Height is the height of the line, e.g. in text rows or in points.
+	Height int
+
+	// Paragraph is the ID of the paragraph of the line. Adjacent lines
+	// with the same ID form a paragraph. Lines with the ID 0 are not part of a paragraph.
+	Paragraph int
+
+	// KeepTogether prevents page breaks within the paragraph of the line.
+	KeepTogether bool
+
+	// KeepWithNext prevents a page break after the line, e.g. for headings.
+	KeepWithNext bool
+}
+
+// Document splits the lines of a document into printed pages.
+// Like FixPage, each page is filled with as many lines as fit, but the page
+// is broken earlier, if the hints of the lines or the widow and orphan control require it.
+// If there is no allowed break on a page, the page is broken where it is full.
+type Document struct {
+	// PageHeight is the height of a page, including the header and the footer.
+	PageHeight int
+
+	// Header and Footer are the heights of the header and the footer of each page.
+	Header, Footer int
+
+	// Orphans is the minimum number of lines of a paragraph at the bottom of a page.
+	Orphans int
+
+	// Widows is the minimum number of lines of a paragraph at the top of a page.
+	Widows int
+}
+
+// Paginate returns the pages of the given lines. The pages that
+// start within a paragraph are marked as Continued.
+// A line that is higher than a page gets a page of its own.
+func (d Document) Paginate(lines []Line) ([]Page, error) {
+	height := d.PageHeight - d.Header - d.Footer
+	if height < 1 {
+		return nil, fmt.Errorf("pager: no space for lines on a page of height %d", d.PageHeight)
+	}
+
+	var pages []Page
+	for from := 0; from < len(lines); {
+		to, used := from, 0
+		for to < len(lines) && (to == from || used+lines[to].Height <= height) {
+			if lines[to].Height < 0 {
+				return nil, fmt.Errorf("pager: negative height of line %d", to)
+			}
+			used += lines[to].Height
+			to++
+		}
+
+		if to < len(lines) {
+			to = d.breakBefore(lines, from, to)
+		}
+
+		pages = append(pages, Page{Number: len(pages), From: from, To: to, Continued: sameParagraph(lines, from)})
+		from = to
+	}
+	return pages, nil
+}
+
+// breakBefore returns the latest allowed page break within (from, to].
+// If there is none, to is returned.
+func (d Document) breakBefore(lines []Line, from, to int) int {
+	for b := to; b > from; b-- {
+		if d.canBreak(lines, b) {
+			return b
+		}
+	}
+	return to
+}
+
+// canBreak returns wether a page may be broken before the line with the given index.
+func (d Document) canBreak(lines []Line, i int) bool {
+	if lines[i-1].KeepWithNext {
+		return false
+	}
+
+	if !sameParagraph(lines, i) {
+		return true
+	}
+
+	start, end := i, i
+	for start > 0 && sameParagraph(lines, start) {
+		start--
+	}
+	for end < len(lines) && (end == i || sameParagraph(lines, end)) {
+		end++
+	}
+
+	for _, l := range lines[start:end] {
+		if l.KeepTogether {
+			return false
+		}
+	}
+
+	return i-start >= d.Orphans && end-i >= d.Widows
+}
+
+// sameParagraph returns wether the line with the given index continues the paragraph of the previous line.
+func sameParagraph(lines []Line, i int) bool {
+	return i > 0 && i < len(lines) && lines[i].Paragraph != 0 && lines[i].Paragraph == lines[i-1].Paragraph
+}
+
+// PageOf returns the number of the page that contains the line with the given index,
+// starting with 1, and the number of pages, e.g. for "page 2 of 5".
+// n is 0, if the line is not on any of the pages.
+func PageOf(pages []Page, line int) (n, of int) {
+	i := sort.Search(len(pages), func(i int) bool { return pages[i].To > line })
+	if i == len(pages) || line < pages[i].From {
+		return 0, len(pages)
+	}
+	return i + 1, len(pages)
+}
@@ -0,0 +1,95 @@
+package pager
+
+import (
+	"reflect"
+	"testing"
+)
+
+// lines returns lines of height 1 of the given paragraphs.
+func lines(paragraphs ...int) []Line {
+	res := make([]Line, len(paragraphs))
+	for i, p := range paragraphs {
+		res[i] = Line{Height: 1, Paragraph: p}
+	}
+	return res
+}
+
+func TestDocumentPaginate(t *testing.T) {
+	heading := lines(0, 0, 0, 0, 0, 0)
+	heading[2].KeepWithNext = true
+
+	together := lines(0, 1, 1, 1, 0)
+	together[1].KeepTogether = true
+
+	tests := []struct {
+		doc   Document
+		lines []Line
+		pages []Page
+	}{
+		{Document{PageHeight: 5, Header: 1, Footer: 1}, lines(0, 0, 0, 0, 0, 0, 0, 0, 0, 0), []Page{{0, 0, 3, false}, {1, 3, 6, false}, {2, 6, 9, false}, {3, 9, 10, false}}},
+		{Document{PageHeight: 3}, heading, []Page{{0, 0, 2, false}, {1, 2, 5, false}, {2, 5, 6, false}}},
+		{Document{PageHeight: 3, Orphans: 2, Widows: 2}, lines(0, 1, 1, 1, 1, 0, 0), []Page{{0, 0, 3, false}, {1, 3, 6, true}, {2, 6, 7, false}}},
+		{Document{PageHeight: 3, Orphans: 2, Widows: 3}, lines(0, 1, 1, 1, 1, 0, 0), []Page{{0, 0, 1, false}, {1, 1, 4, false}, {2, 4, 7, true}}},
+		{Document{PageHeight: 3}, lines(0, 1, 1, 1, 1, 0, 0), []Page{{0, 0, 3, false}, {1, 3, 6, true}, {2, 6, 7, false}}},
+		{Document{PageHeight: 3}, together, []Page{{0, 0, 1, false}, {1, 1, 4, false}, {2, 4, 5, false}}},
+		{Document{PageHeight: 4}, []Line{{Height: 2}, {Height: 2}, {Height: 2}, {Height: 5}, {Height: 1}}, []Page{{0, 0, 2, false}, {1, 2, 3, false}, {2, 3, 4, false}, {3, 4, 5, false}}},
+		{Document{PageHeight: 4}, nil, nil},
+	}
+
+	for i, test := range tests {
+		got, err := test.doc.Paginate(test.lines)
+		if err != nil {
+			t.Errorf("[%v] Paginate(); err = %v", i, err)
+			continue
+		}
+
+		if want := test.pages; !reflect.DeepEqual(got, want) {
+			t.Errorf("[%v] Paginate() = %v; want %v", i, got, want)
+		}
+	}
+}
+
+func TestDocumentPaginateErrors(t *testing.T) {
+	tests := []struct {
+		doc   Document
+		lines []Line
+	}{
+		{Document{PageHeight: 2, Header: 1, Footer: 1}, lines(0)},
+		{Document{PageHeight: 3}, []Line{{Height: 1}, {Height: -1}}},
+	}
+
+	for _, test := range tests {
+		if _, err := test.doc.Paginate(test.lines); err == nil {
+			t.Errorf("%+v.Paginate(%v); err = nil", test.doc, test.lines)
+		}
+	}
+}
+
+func TestPageOf(t *testing.T) {
+	pages := []Page{{0, 0, 2, false}, {1, 2, 5, false}, {2, 5, 6, false}}
+
+	tests := []struct {
+		line int
+		n    int
+	}{
+		{0, 1},
+		{1, 1},
+		{2, 2},
+		{4, 2},
+		{5, 3},
+		{6, 0},
+		{-1, 0},
+	}
+
+	for _, test := range tests {
+		n, of := PageOf(pages, test.line)
+
+		if got, want := n, test.n; got != want {
+			t.Errorf("PageOf(%v) = %v; want %v", test.line, got, want)
+		}
+
+		if got, want := of, 3; got != want {
+			t.Errorf("PageOf(%v); of = %v; want %v", test.line, got, want)
+		}
+	}
+}
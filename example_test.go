@@ -31,3 +31,26 @@ func Example() {
 	// > five
 	//   six
 }
+
+func ExampleSlice() {
+	fmt.Println("")
+
+	pg := pager.NewSlice(3, []string{"one", "two", "three", "four", "five", "six"}, pager.PreSelect(5))
+
+	pg.Prev()
+
+	current, _ := pg.Current()
+
+	for _, line := range pg.Visible() {
+		prefix := "  "
+		if line == current {
+			prefix = "> "
+		}
+		fmt.Println(prefix + line)
+	}
+
+	// Output:
+	//   four
+	// > five
+	//   six
+}
@@ -1 +1,3 @@
 module github.com/metakeule/pager
+
+go 1.23
@@ -0,0 +1,152 @@
+package pager
+
+import (
+	"slices"
+)
+
+// Groups groups the items, e.g. the invoices of a customer.
+// group returns the ID of the group of the item with the given index.
+// The items of a group must be adjacent.
+//
+// With StyleFixPage, a page ends before a group that does not fit on the rest
+// of the page, so that a group is only split, if it does not fit on a page at all.
+// The pages that continue a group are marked as Continued.
+// NextGroup and PrevGroup move between the groups.
+func Groups(group func(index int) string) Option {
+	return func(pg *pager) {
+		pg.group = group
+	}
+}
+
+// NextGroup selects the first item of the next group. Returns wether the selected item has changed.
+func NextGroup(pg Dispatcher) (changed bool) {
+	return pg.Dispatch(ActNextGroup()).Changed()
+}
+
+// PrevGroup selects the first item of the group or, if it is already selected,
+// of the previous group. Returns wether the selected item has changed.
+func PrevGroup(pg Dispatcher) (changed bool) {
+	return pg.Dispatch(ActPrevGroup()).Changed()
+}
+
+// regroup sets the first indexes of the groups and of the pages.
+func (s State) regroup(group func(int) string) State {
+	var starts []int
+	var last string
+	for i := range s.Len {
+		id := group(i)
+		if i == 0 || id != last {
+			starts = append(starts, i)
+		}
+		last = id
+	}
+	s.GroupStarts = starts
+	s.PageStarts = s.pageStarts()
+	return s
+}
+
+// shiftGroups moves the first indexes of the groups (and the pages) along
+// with the items, after the action has changed the length from oldLen.
+// The inserted or appended items are treated as a new group, while the
+// items that follow them start a group of their own.
+func (s State) shiftGroups(a Action, oldLen int) State {
+	var starts []int
+
+	switch a.Op {
+	case OpInsert:
+		n := s.Len - oldLen
+		for _, i := range s.GroupStarts {
+			starts = append(starts, shiftInsert(i, a.At, n))
+		}
+		starts = append(starts, a.At, a.At+n)
+	case OpDelete:
+		n := oldLen - s.Len
+		for _, i := range s.GroupStarts {
+			if j, ok := shiftDelete(i, a.At, n); ok {
+				starts = append(starts, j)
+			}
+		}
+
+		// the rest of a group whose start has been deleted
+		if k, found := slices.BinarySearch(s.GroupStarts, a.At+n); !found && k > 0 && s.GroupStarts[k-1] >= a.At {
+			starts = append(starts, a.At)
+		}
+	default:
+		starts = append(starts, s.GroupStarts...)
+		if s.Len > oldLen {
+			starts = append(starts, oldLen)
+		}
+	}
+
+	slices.Sort(starts)
+	starts = slices.Compact(starts)
+	if len(starts) == 0 || starts[0] != 0 {
+		starts = slices.Insert(starts, 0, 0)
+	}
+
+	i, _ := slices.BinarySearch(starts, s.Len)
+	s.GroupStarts = starts[:i]
+	if s.Len == 0 {
+		s.GroupStarts = nil
+	}
+	s.PageStarts = s.pageStarts()
+	return s
+}
+
+// pageStarts returns the first indexes of the pages of grouped items.
+// A page ends before the group that does not fit on it,
+// unless the group starts at the beginning of the page.
+// It returns nil, if the items are not grouped.
+func (s State) pageStarts() []int {
+	if len(s.GroupStarts) == 0 {
+		return nil
+	}
+
+	var starts []int
+	for from := 0; from < s.Len; {
+		starts = append(starts, from)
+		to := from + max(s.Height, 1)
+		if to < s.Len {
+			i, found := slices.BinarySearch(s.GroupStarts, to)
+			if !found && i > 0 && s.GroupStarts[i-1] > from {
+				to = s.GroupStarts[i-1]
+			}
+		}
+		from = to
+	}
+	return starts
+}
+
+// groupedPages returns wether the viewport shows the pages of grouped items,
+// which may be shorter than the height.
+func (s State) groupedPages() bool {
+	return s.Style == StyleFixPage && !s.NoSelection && len(s.PageStarts) > 0
+}
+
+// continued returns wether the item with the given index continues the group of the previous item.
+func (s State) continued(index int) bool {
+	if index <= 0 || len(s.GroupStarts) == 0 {
+		return false
+	}
+	_, found := slices.BinarySearch(s.GroupStarts, index)
+	return !found
+}
+
+// nextGroup returns the first index of the group after the item with the given index.
+func (s State) nextGroup(index int) (int, bool) {
+	i, _ := slices.BinarySearch(s.GroupStarts, index+1)
+	if i < len(s.GroupStarts) && s.GroupStarts[i] < s.Len {
+		return s.GroupStarts[i], true
+	}
+	return -1, false
+}
+
+// prevGroup returns the first index of the group of the item with the given index or,
+// if that is the given index, of the previous group.
+func (s State) prevGroup(index int) (int, bool) {
+	i, _ := slices.BinarySearch(s.GroupStarts, index)
+	if i > 0 {
+		return s.GroupStarts[i-1], true
+	}
+	return -1, false
+}
@@ -0,0 +1,138 @@
+package pager
+
+import (
+	"reflect"
+	"testing"
+)
+
+// customers groups the data into groups of the given sizes, named a, b, c, ...
+func customers(sizes ...int) func(int) string {
+	var ids []string
+	for i, n := range sizes {
+		for range n {
+			ids = append(ids, string(rune('a'+i)))
+		}
+	}
+	return func(index int) string { return ids[index] }
+}
+
+func TestGroupStart(t *testing.T) {
+	s := NewState(10, 10, StyleFixPage).regroup(customers(3, 1, 4, 2))
+
+	tests := []struct {
+		index int
+		start int
+	}{
+		{0, 0},
+		{2, 0},
+		{3, 3},
+		{4, 4},
+		{7, 4},
+		{9, 8},
+	}
+
+	for _, test := range tests {
+		if got, _ := s.prevGroup(test.index + 1); got != test.start {
+			t.Errorf("prevGroup(%v) = %v; want %v", test.index+1, got, test.start)
+		}
+	}
+}
+
+func TestGroupPages(t *testing.T) {
+	tests := []struct {
+		height int
+		sizes  []int
+		pages  []Page
+	}{
+		{3, []int{3, 1, 4, 2}, []Page{{0, 0, 3, false}, {1, 3, 4, false}, {2, 4, 7, false}, {3, 7, 10, true}}},
+		{4, []int{3, 1, 4, 2}, []Page{{0, 0, 4, false}, {1, 4, 8, false}, {2, 8, 10, false}}},
+		{4, []int{2, 3, 5}, []Page{{0, 0, 2, false}, {1, 2, 5, false}, {2, 5, 9, false}, {3, 9, 10, true}}},
+		{5, []int{10}, []Page{{0, 0, 5, false}, {1, 5, 10, true}}},
+	}
+
+	for _, test := range tests {
+		pg := New(test.height, len(data), Groups(customers(test.sizes...)))
+
+		var got []Page
+		for page := range pg.State().Pages() {
+			got = append(got, page)
+		}
+
+		if want := test.pages; !reflect.DeepEqual(got, want) {
+			t.Errorf("height %v, groups %v; Pages() = %v; want %v", test.height, test.sizes, got, want)
+		}
+
+		for _, page := range test.pages {
+			Select(pg, page.From)
+
+			if from, to, _ := pg.Indexes(); from != page.From || to != page.To {
+				t.Errorf("height %v, groups %v; Select(%v); from: %v, to: %v; want %v, %v", test.height, test.sizes, page.From, from, to, page.From, page.To)
+			}
+
+			if current, pages := pg.State().Page(); current != page || pages != len(test.pages) {
+				t.Errorf("height %v, groups %v; Select(%v); Page() = %v, %v; want %v, %v", test.height, test.sizes, page.From, current, pages, page, len(test.pages))
+			}
+		}
+	}
+}
+
+func TestGroupPaging(t *testing.T) {
+	pg := New(3, len(data), Groups(customers(3, 1, 4, 2)))
+
+	tests := []struct {
+		move     func(Dispatcher) bool
+		changed  bool
+		lines    []string
+		selected string
+	}{
+		{Dispatcher.PageDown, true, []string{"four"}, "four"},
+		{Dispatcher.PageDown, true, []string{"five", "six", "seven"}, "seven"},
+		{Dispatcher.PageUp, true, []string{"four"}, "four"},
+		{NextGroup, true, []string{"five", "six", "seven"}, "five"},
+		{NextGroup, true, []string{"eight", "nine", "ten"}, "nine"},
+		{NextGroup, false, []string{"eight", "nine", "ten"}, "nine"},
+		{Dispatcher.Next, true, []string{"eight", "nine", "ten"}, "ten"},
+		{PrevGroup, true, []string{"eight", "nine", "ten"}, "nine"},
+		{PrevGroup, true, []string{"five", "six", "seven"}, "five"},
+		{PrevGroup, true, []string{"four"}, "four"},
+		{PrevGroup, true, []string{"one", "two", "three"}, "one"},
+		{PrevGroup, false, []string{"one", "two", "three"}, "one"},
+	}
+
+	for i, test := range tests {
+		if got, want := test.move(pg), test.changed; got != want {
+			t.Errorf("[%v] changed = %v; want %v", i, got, want)
+		}
+
+		lines, selected := displayData(pg)
+
+		if got, want := lines, test.lines; !reflect.DeepEqual(got, want) {
+			t.Errorf("[%v] lines = %#v; want %#v", i, got, want)
+		}
+
+		if got, want := selected, test.selected; got != want {
+			t.Errorf("[%v] selected = %#v; want %#v", i, got, want)
+		}
+	}
+}
+
+func TestGroupDataChanges(t *testing.T) {
+	sizes := []int{3, 1, 4, 2}
+	pg := New(3, 8, Groups(func(i int) string { return customers(sizes...)(i) }))
+
+	if _, pages := pg.State().Page(); pages != 4 {
+		t.Errorf("pages = %v; want 4", pages)
+	}
+
+	Select(pg, 6)
+	sizes = []int{2, 2, 4, 2}
+	SetLen(pg, len(data))
+
+	if from, to, _ := pg.Indexes(); from != 4 || to != 7 {
+		t.Errorf("after SetLen; from: %v, to: %v; want 4, 7", from, to)
+	}
+
+	if _, pages := pg.State().Page(); pages != 4 {
+		t.Errorf("after SetLen; pages = %v; want 4", pages)
+	}
+}
@@ -0,0 +1,134 @@
+package pager
+
+// History is a Dispatcher that keeps a jump list, like the back and forward
+// buttons of a browser or Ctrl-O and Ctrl-I in vim.
+//
+// Before each move of more than a single item, the selected index is recorded.
+// Scrolling is not recorded, even if it drags the selection along.
+// Back returns to the recorded positions, Forward reverts Back.
+// A new jump discards the positions that can be reached via Forward.
+// The positions follow their items through Insert and Delete. Positions of deleted
+// items and positions that are beyond the data after SetLen are discarded.
+type History struct {
+	wrapper
+	capacity      int
+	back, forward []int
+}
+
+// NewHistory returns a pager that keeps a jump list of the given capacity
+// for the given pager. A negative capacity is treated as 0.
+func NewHistory(p Dispatcher, capacity int) *History {
+	h := &History{capacity: max(capacity, 0)}
+	h.wrapper = wrapper{p, h.record}
+	return h
+}
+
+func (h *History) record(a Action) Effect {
+	before, oldLen := h.selected(), h.State().Len
+	effect := h.Dispatcher.Dispatch(a)
+	after, newLen := h.selected(), h.State().Len
+
+	switch a.Op {
+	case OpResize, OpAppend, OpComplete, OpScrollDown, OpScrollUp:
+	case OpSetLen:
+		h.back = h.shift(h.back, func(i int) (int, bool) { return i, i < newLen })
+		h.forward = h.shift(h.forward, func(i int) (int, bool) { return i, i < newLen })
+	case OpInsert:
+		// ignored insertions don't change the length
+		if n := newLen - oldLen; n > 0 {
+			insert := func(i int) (int, bool) { return shiftInsert(i, a.At, n), true }
+			h.back, h.forward = h.shift(h.back, insert), h.shift(h.forward, insert)
+		}
+	case OpDelete:
+		if n := oldLen - newLen; n > 0 {
+			del := func(i int) (int, bool) { return shiftDelete(i, a.At, n) }
+			h.back, h.forward = h.shift(h.back, del), h.shift(h.forward, del)
+		}
+	default:
+		if before > -1 && after > -1 && (after-before > 1 || before-after > 1) {
+			h.back = h.push(h.back, before)
+			h.forward = nil
+		}
+	}
+
+	return effect
+}
+
+// Back returns to the previous recorded position. Returns wether the selected item has changed.
+func (h *History) Back() (changed bool) {
+	return h.jump(&h.back, &h.forward)
+}
+
+// Forward reverts the last Back. Returns wether the selected item has changed.
+func (h *History) Forward() (changed bool) {
+	return h.jump(&h.forward, &h.back)
+}
+
+// CanBack returns wether there is a position to go back to.
+func (h *History) CanBack() bool {
+	return len(h.skip(h.back)) > 0
+}
+
+// CanForward returns wether there is a position to go forward to.
+func (h *History) CanForward() bool {
+	return len(h.skip(h.forward)) > 0
+}
+
+// jump selects the last position of from and records the current position in to.
+func (h *History) jump(from, to *[]int) bool {
+	*from = h.skip(*from)
+	if len(*from) == 0 {
+		return false
+	}
+
+	target := (*from)[len(*from)-1]
+	*from = (*from)[:len(*from)-1]
+	*to = h.push(*to, h.selected())
+
+	return Select(h.Dispatcher, target)
+}
+
+// skip removes the trailing positions that are invalid or equal to the current one.
+func (h *History) skip(positions []int) []int {
+	cur, dataLen := h.selected(), h.State().Len
+	for len(positions) > 0 {
+		last := positions[len(positions)-1]
+		if last != cur && last < dataLen {
+			break
+		}
+		positions = positions[:len(positions)-1]
+	}
+	return positions
+}
+
+// shift moves the positions via fn, removing those for which fn returns false.
+func (h *History) shift(positions []int, fn func(int) (int, bool)) []int {
+	res := positions[:0]
+	for _, pos := range positions {
+		if pos, ok := fn(pos); ok {
+			res = append(res, pos)
+		}
+	}
+	return res
+}
+
+// push appends the position, dropping the oldest ones beyond the capacity.
+func (h *History) push(positions []int, pos int) []int {
+	if pos < 0 || (len(positions) > 0 && positions[len(positions)-1] == pos) {
+		return positions
+	}
+
+	positions = append(positions, pos)
+	if over := len(positions) - h.capacity; over > 0 {
+		positions = append(positions[:0], positions[over:]...)
+	}
+	return positions
+}
+
+func (h *History) selected() int {
+	from, _, selected := h.Indexes()
+	if from == -1 || selected == -1 {
+		return -1
+	}
+	return from + selected
+}
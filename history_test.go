@@ -0,0 +1,156 @@
+package pager
+
+import (
+	"reflect"
+	"testing"
+)
+
+func selectedIndex(pg Pager) int {
+	from, _, selected := pg.Indexes()
+	if from == -1 {
+		return -1
+	}
+	return from + selected
+}
+
+func TestHistory(t *testing.T) {
+	h := NewHistory(New(3, len(data)), 10)
+
+	h.Next()
+	h.Next()
+	Last(h)
+	h.Prev()
+	Select(h, 4)
+
+	steps := []struct {
+		move     func() bool
+		name     string
+		selected int
+		changed  bool
+	}{
+		{h.Back, "Back", 8, true},
+		{h.Back, "Back", 2, true},
+		{h.Back, "Back", 2, false},
+		{h.Forward, "Forward", 8, true},
+		{h.Forward, "Forward", 4, true},
+		{h.Forward, "Forward", 4, false},
+		{h.Back, "Back", 8, true},
+	}
+
+	for i, step := range steps {
+		changed := step.move()
+
+		if got, want := selectedIndex(h), step.selected; got != want {
+			t.Errorf("[%v] %s(); selected = %v; want %v", i, step.name, got, want)
+		}
+
+		if got, want := changed, step.changed; got != want {
+			t.Errorf("[%v] %s(); changed = %v; want %v", i, step.name, got, want)
+		}
+	}
+
+	// a new jump discards the forward positions
+	First(h)
+
+	if h.CanForward() {
+		t.Errorf("CanForward() = true; want false")
+	}
+
+	h.Back()
+
+	if got, want := selectedIndex(h), 8; got != want {
+		t.Errorf("Back() after First(); selected = %v; want %v", got, want)
+	}
+}
+
+func TestHistoryCapacity(t *testing.T) {
+	h := NewHistory(New(3, len(data)), 2)
+
+	Select(h, 3)
+	Select(h, 6)
+	Select(h, 9)
+	Select(h, 0)
+
+	var visited []int
+	for h.Back() {
+		visited = append(visited, selectedIndex(h))
+	}
+
+	if got, want := visited, []int{9, 6}; !reflect.DeepEqual(got, want) {
+		t.Errorf("visited = %v; want %v", got, want)
+	}
+}
+
+func TestHistoryInvalid(t *testing.T) {
+	h := NewHistory(New(3, len(data)), 10)
+
+	Select(h, 2)
+	Select(h, 8)
+	Select(h, 5)
+	SetLen(h, 6)
+
+	if !h.Back() {
+		t.Fatalf("Back() = false; want true")
+	}
+
+	if got, want := selectedIndex(h), 2; got != want {
+		t.Errorf("selected = %v; want %v", got, want)
+	}
+
+	SetLen(h, 4)
+
+	// the forward position 5 is beyond the data
+	if h.CanForward() {
+		t.Errorf("CanForward() = true; want false")
+	}
+}
+
+func TestHistoryInsertDelete(t *testing.T) {
+	h := NewHistory(New(3, len(data)), 10)
+
+	Select(h, 2)
+	Select(h, 8)
+	Select(h, 5)
+
+	Insert(h, 0, 2)
+	Delete(h, 9, 3)
+
+	var visited []int
+	for h.Back() {
+		visited = append(visited, selectedIndex(h))
+	}
+
+	if got, want := visited, []int{4, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("visited = %v; want %v", got, want)
+	}
+}
+
+func TestHistoryNegativeCapacity(t *testing.T) {
+	h := NewHistory(New(3, len(data)), -1)
+
+	Select(h, 3)
+	Select(h, 9)
+
+	if h.CanBack() {
+		t.Errorf("CanBack() = true; want false")
+	}
+}
+
+func TestHistoryIgnoredInsertDelete(t *testing.T) {
+	h := NewHistory(New(3, len(data)), 10)
+
+	Select(h, 2)
+	Select(h, 8)
+
+	Insert(h, 20, 2)
+	Delete(h, -1, 3)
+	Insert(h, 0, -1)
+
+	if !h.Back() {
+		t.Fatalf("Back() = false; want true")
+	}
+
+	if got, want := selectedIndex(h), 2; got != want {
+		t.Errorf("selected = %v; want %v", got, want)
+	}
+}
@@ -0,0 +1,326 @@
+package pager
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Query describes the names of the paging parameters within
+// an URL query and the allowed page sizes.
+//
+// A query is either page based (Page, PerPage), where pages start with 1,
+// or offset based (Offset, Limit), where offsets start with 0.
+type Query struct {
+	Page, PerPage string
+	Offset, Limit string
+
+	// DefaultPerPage is the page size, if neither PerPage nor Limit is given.
+	// If it is not positive, 30 is used (but not more than MaxPerPage).
+	DefaultPerPage int
+
+	// MaxPerPage is the largest allowed page size.
+	MaxPerPage int
+}
+
+// DefaultQuery uses the parameters page, per_page, offset and limit
+// with a default page size of 30 and a maximum of 100.
+var DefaultQuery = Query{
+	Page:           "page",
+	PerPage:        "per_page",
+	Offset:         "offset",
+	Limit:          "limit",
+	DefaultPerPage: defaultPerPage,
+	MaxPerPage:     100,
+}
+
+const defaultPerPage = 30
+
+// QueryError is returned for an invalid paging parameter.
+type QueryError struct {
+	Param  string
+	Value  string
+	Reason string
+}
+
+// Error returns the error message.
+func (e *QueryError) Error() string {
+	return fmt.Sprintf("pager: invalid query parameter %s=%q: %s", e.Param, e.Value, e.Reason)
+}
+
+// StatusCode returns the HTTP status code that fits the error (400 Bad Request).
+func (e *QueryError) StatusCode() int {
+	return http.StatusBadRequest
+}
+
+// FromRequest creates a pager for the page requested by the query of r.
+// See Parse.
+func (q Query) FromRequest(r *http.Request, dataLen int) (Dispatcher, error) {
+	return q.Parse(r.URL.Query(), dataLen)
+}
+
+// Parse creates a pager for the page requested by the given query values.
+// The returned pager uses the Top style, so that Indexes returns the requested
+// offset as from. If the requested page is beyond the data, Indexes reports no data.
+// Invalid parameters result in a *QueryError.
+func (q Query) Parse(v url.Values, dataLen int) (Dispatcher, error) {
+	height, err := q.perPage(v)
+	if err != nil {
+		return nil, err
+	}
+
+	if q.has(v, q.Page) && q.has(v, q.Offset) {
+		return nil, &QueryError{q.Offset, v.Get(q.Offset), "can't be combined with " + q.Page}
+	}
+
+	var offset int
+
+	switch {
+	case q.has(v, q.Page):
+		page, err := q.int(v, q.Page, 1)
+		if err != nil {
+			return nil, err
+		}
+		if page-1 > math.MaxInt/height {
+			return nil, &QueryError{q.Page, v.Get(q.Page), "too large"}
+		}
+		offset = (page - 1) * height
+	case q.has(v, q.Offset):
+		offset, err = q.int(v, q.Offset, 0)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return New(height, dataLen, Top(), PreSelect(uint(offset))), nil
+}
+
+// Values returns the query values for the first, previous, next and last page
+// of the given pager. The values only contain the paging parameters, use Encode
+// to get the query string. prev and next are nil if there is no such page.
+//
+// If the current page of pg starts at a multiple of the page size, the page based
+// parameters are used, otherwise the offset based ones. The pages of grouped items
+// (see Groups) use the offset based parameters, if there are any.
+func (q Query) Values(pg Dispatcher) (first, prev, next, last url.Values) {
+	s := pg.State()
+	firstOff, prevOff, nextOff, lastOff := offsets(s)
+	height := s.Height
+	grouped := len(s.PageStarts) > 0
+
+	from, _, _ := s.Indexes()
+	usePage := q.Offset == "" || (q.Page != "" && !grouped && (from == -1 || from%height == 0))
+
+	values := func(offset int) url.Values {
+		if offset < 0 {
+			return nil
+		}
+
+		page := offset/height + 1
+		if grouped {
+			n, _, _ := s.pageOf(offset)
+			page = n + 1
+		}
+
+		v := url.Values{}
+		q.set(v, offset, page, height, usePage)
+		return v
+	}
+
+	return values(firstOff), values(prevOff), values(nextOff), values(lastOff)
+}
+
+// set sets the paging parameters for the given offset (or page) and page size within v.
+// Parameters without a name are skipped.
+func (q Query) set(v url.Values, offset, page, height int, usePage bool) {
+	set := func(param string, value int) {
+		if param != "" {
+			v.Set(param, strconv.Itoa(value))
+		}
+	}
+
+	if usePage {
+		set(q.Page, page)
+		set(q.PerPage, height)
+		return
+	}
+
+	limit := q.Limit
+	if limit == "" {
+		limit = q.PerPage
+	}
+	set(q.Offset, offset)
+	set(limit, height)
+}
+
+func (q Query) perPage(v url.Values) (int, error) {
+	if q.has(v, q.PerPage) && q.has(v, q.Limit) {
+		return 0, &QueryError{q.Limit, v.Get(q.Limit), "can't be combined with " + q.PerPage}
+	}
+
+	param := q.PerPage
+	if q.has(v, q.Limit) {
+		param = q.Limit
+	}
+
+	if !q.has(v, param) {
+		if q.DefaultPerPage > 0 {
+			return q.DefaultPerPage, nil
+		}
+		if q.MaxPerPage > 0 {
+			return min(defaultPerPage, q.MaxPerPage), nil
+		}
+		return defaultPerPage, nil
+	}
+
+	height, err := q.int(v, param, 1)
+	if err != nil {
+		return 0, err
+	}
+
+	if q.MaxPerPage > 0 && height > q.MaxPerPage {
+		return 0, &QueryError{param, v.Get(param), "must not be larger than " + strconv.Itoa(q.MaxPerPage)}
+	}
+	return height, nil
+}
+
+func (q Query) has(v url.Values, param string) bool {
+	return param != "" && v.Get(param) != ""
+}
+
+func (q Query) int(v url.Values, param string, min int) (int, error) {
+	s := v.Get(param)
+	i, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, &QueryError{param, s, "not an integer"}
+	}
+
+	if i < min {
+		return 0, &QueryError{param, s, "must not be smaller than " + strconv.Itoa(min)}
+	}
+	return i, nil
+}
+
+// offsets returns the offsets of the first, previous, next and last page
+// relative to the current page of s. A missing page has the offset -1.
+// The pages are aligned to the offset of the current page, unless the items are grouped.
+func offsets(s State) (first, prev, next, last int) {
+	if len(s.PageStarts) > 0 {
+		return groupOffsets(s)
+	}
+
+	height, dataLen := s.Height, s.Len
+	from, _, _ := s.Indexes()
+
+	start := 0
+	if from > 0 {
+		start = from % height
+	}
+
+	if dataLen > start {
+		last = start + (dataLen-1-start)/height*height
+	}
+
+	prev, next = -1, -1
+
+	switch {
+	case from == -1:
+		if dataLen > 0 {
+			prev = last
+		}
+	case from > 0:
+		prev = from - height
+		if prev < 0 {
+			prev = 0
+		}
+	}
+
+	if from > -1 && from+height < dataLen {
+		next = from + height
+	}
+
+	return 0, prev, next, last
+}
+
+// groupOffsets is offsets for the pages of grouped items, see Pages.
+func groupOffsets(s State) (first, prev, next, last int) {
+	from, _, _ := s.Indexes()
+	last = s.PageStarts[len(s.PageStarts)-1]
+	prev, next = -1, -1
+
+	if from == -1 {
+		return 0, last, next, last
+	}
+
+	page, start, to := s.pageOf(from)
+	switch {
+	case start < from:
+		prev = start
+	case page > 0:
+		prev = s.PageStarts[page-1]
+	}
+
+	if to < s.Len {
+		next = to
+	}
+	return 0, prev, next, last
+}
+
+// Link returns the value of a RFC 8288 Link header with the relations
+// first, prev, next and last for the given pager.
+// The links are based on the given URL, whose paging parameters are replaced,
+// while all other query parameters are kept.
+func (q Query) Link(base *url.URL, pg Dispatcher) string {
+	first, prev, next, last := q.Values(pg)
+
+	var bf strings.Builder
+
+	for _, l := range []struct {
+		rel    string
+		values url.Values
+	}{
+		{"first", first},
+		{"prev", prev},
+		{"next", next},
+		{"last", last},
+	} {
+		if l.values == nil {
+			continue
+		}
+
+		if bf.Len() > 0 {
+			bf.WriteString(", ")
+		}
+
+		fmt.Fprintf(&bf, "<%s>; rel=%q", q.url(base, l.values), l.rel)
+	}
+
+	return bf.String()
+}
+
+// SetHeaders sets the Link header (see Link) and the X-Total-Count header,
+// which contains the length of the data.
+func (q Query) SetHeaders(h http.Header, base *url.URL, pg Dispatcher) {
+	h.Set("Link", q.Link(base, pg))
+	h.Set("X-Total-Count", strconv.Itoa(pg.State().Len))
+}
+
+// url returns a copy of base, where the paging parameters are replaced by the given values.
+func (q Query) url(base *url.URL, values url.Values) string {
+	u := *base
+	v := u.Query()
+
+	for _, param := range []string{q.Page, q.PerPage, q.Offset, q.Limit} {
+		v.Del(param)
+	}
+
+	for param := range values {
+		v.Set(param, values.Get(param))
+	}
+
+	u.RawQuery = v.Encode()
+	return u.String()
+}
@@ -0,0 +1,260 @@
+package pager
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestQueryParse(t *testing.T) {
+	tests := []struct {
+		query    string
+		dataLen  int
+		from, to int
+		height   int
+	}{
+		{"", 100, 0, 30, 30},
+		{"page=1", 100, 0, 30, 30},
+		{"page=2&per_page=10", 100, 10, 20, 10},
+		{"page=4", 100, 90, 100, 30},
+		{"page=5", 100, -1, -1, 30},
+		{"offset=5&limit=10", 100, 5, 15, 10},
+		{"offset=95&limit=10", 100, 95, 100, 10},
+		{"offset=7&per_page=3", 10, 7, 10, 3},
+		{"per_page=100", 10, 0, 10, 100},
+		{"", 0, -1, -1, 30},
+	}
+
+	for _, test := range tests {
+		v, _ := url.ParseQuery(test.query)
+		pg, err := DefaultQuery.Parse(v, test.dataLen)
+
+		if err != nil {
+			t.Errorf("Parse(%q); err = %v", test.query, err)
+			continue
+		}
+
+		from, to, _ := pg.Indexes()
+
+		if from != test.from || to != test.to {
+			t.Errorf("Parse(%q); from, to = %v, %v; want %v, %v", test.query, from, to, test.from, test.to)
+		}
+
+		if got, want := pg.State().Height, test.height; got != want {
+			t.Errorf("Parse(%q); height = %v; want %v", test.query, got, want)
+		}
+	}
+}
+
+func TestQueryParseDefaultPerPage(t *testing.T) {
+	tests := []struct {
+		query  Query
+		height int
+	}{
+		{Query{Page: "page"}, 30},
+		{Query{Page: "page", DefaultPerPage: -1, MaxPerPage: 10}, 10},
+		{Query{Page: "page", DefaultPerPage: 5}, 5},
+	}
+
+	for _, test := range tests {
+		pg, err := test.query.Parse(url.Values{"page": {"2"}}, 100)
+		if err != nil {
+			t.Errorf("%+v.Parse(); err = %v", test.query, err)
+			continue
+		}
+
+		if got, want := pg.State().Height, test.height; got != want {
+			t.Errorf("%+v.Parse(); height = %v; want %v", test.query, got, want)
+		}
+
+		if from, _, _ := pg.Indexes(); from != test.height {
+			t.Errorf("%+v.Parse(); from = %v; want %v", test.query, from, test.height)
+		}
+	}
+}
+
+func TestQueryParseErrors(t *testing.T) {
+	tests := []struct {
+		query string
+		param string
+	}{
+		{"page=0", "page"},
+		{"page=x", "page"},
+		{"page=9223372036854775807", "page"},
+		{"offset=-1", "offset"},
+		{"per_page=0", "per_page"},
+		{"per_page=101", "per_page"},
+		{"limit=101", "limit"},
+		{"page=1&offset=2", "offset"},
+		{"per_page=1&limit=2", "limit"},
+	}
+
+	for _, test := range tests {
+		r := httptest.NewRequest("GET", "/items?"+test.query, nil)
+		_, err := DefaultQuery.FromRequest(r, 100)
+
+		qerr, ok := err.(*QueryError)
+		if !ok {
+			t.Errorf("FromRequest(%q); err = %#v; want *QueryError", test.query, err)
+			continue
+		}
+
+		if got, want := qerr.Param, test.param; got != want {
+			t.Errorf("FromRequest(%q); err.Param = %q; want %q", test.query, got, want)
+		}
+
+		if got, want := qerr.StatusCode(), 400; got != want {
+			t.Errorf("FromRequest(%q); err.StatusCode() = %v; want %v", test.query, got, want)
+		}
+	}
+}
+
+func TestQueryValues(t *testing.T) {
+	encode := func(v url.Values) string {
+		if v == nil {
+			return "-"
+		}
+		return v.Encode()
+	}
+
+	tests := []struct {
+		query                   string
+		dataLen                 int
+		first, prev, next, last string
+	}{
+		{"page=1&per_page=10", 35, "page=1&per_page=10", "-", "page=2&per_page=10", "page=4&per_page=10"},
+		{"page=2&per_page=10", 35, "page=1&per_page=10", "page=1&per_page=10", "page=3&per_page=10", "page=4&per_page=10"},
+		{"page=4&per_page=10", 35, "page=1&per_page=10", "page=3&per_page=10", "-", "page=4&per_page=10"},
+		{"page=9&per_page=10", 35, "page=1&per_page=10", "page=4&per_page=10", "-", "page=4&per_page=10"},
+		{"page=1&per_page=10", 0, "page=1&per_page=10", "-", "-", "page=1&per_page=10"},
+		{"offset=5&limit=10", 35, "limit=10&offset=0", "limit=10&offset=0", "limit=10&offset=15", "limit=10&offset=25"},
+		{"offset=25&limit=10", 35, "limit=10&offset=0", "limit=10&offset=15", "-", "limit=10&offset=25"},
+	}
+
+	for _, test := range tests {
+		v, _ := url.ParseQuery(test.query)
+		pg, err := DefaultQuery.Parse(v, test.dataLen)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		first, prev, next, last := DefaultQuery.Values(pg)
+
+		if got, want := encode(first), test.first; got != want {
+			t.Errorf("Values(%q); first = %q; want %q", test.query, got, want)
+		}
+
+		if got, want := encode(prev), test.prev; got != want {
+			t.Errorf("Values(%q); prev = %q; want %q", test.query, got, want)
+		}
+
+		if got, want := encode(next), test.next; got != want {
+			t.Errorf("Values(%q); next = %q; want %q", test.query, got, want)
+		}
+
+		if got, want := encode(last), test.last; got != want {
+			t.Errorf("Values(%q); last = %q; want %q", test.query, got, want)
+		}
+	}
+}
+
+func TestQueryValuesGroups(t *testing.T) {
+	pg := New(3, 10, Groups(customers(2, 2, 2, 2, 2)), PreSelect(3))
+	pageQuery := Query{Page: "page", PerPage: "per_page"}
+
+	tests := []struct {
+		query                   Query
+		first, prev, next, last string
+	}{
+		{DefaultQuery, "limit=3&offset=0", "limit=3&offset=0", "limit=3&offset=4", "limit=3&offset=8"},
+		{pageQuery, "page=1&per_page=3", "page=1&per_page=3", "page=3&per_page=3", "page=5&per_page=3"},
+	}
+
+	for _, test := range tests {
+		first, prev, next, last := test.query.Values(pg)
+
+		for _, v := range []struct {
+			name      string
+			got, want string
+		}{
+			{"first", first.Encode(), test.first},
+			{"prev", prev.Encode(), test.prev},
+			{"next", next.Encode(), test.next},
+			{"last", last.Encode(), test.last},
+		} {
+			if v.got != v.want {
+				t.Errorf("%+v.Values(); %s = %q; want %q", test.query, v.name, v.got, v.want)
+			}
+		}
+	}
+}
+
+func TestQueryValuesUnnamed(t *testing.T) {
+	q := Query{Page: "page"}
+	pg := New(30, 100, Top(), PreSelect(30))
+
+	_, _, next, _ := q.Values(pg)
+
+	if got, want := next.Encode(), "page=3"; got != want {
+		t.Errorf("Values(); next = %q; want %q", got, want)
+	}
+
+	u, _ := url.Parse("/items?page=2")
+	if got, want := q.Link(u, pg), `</items?page=1>; rel="first", </items?page=1>; rel="prev", </items?page=3>; rel="next", </items?page=4>; rel="last"`; got != want {
+		t.Errorf("Link() = %q; want %q", got, want)
+	}
+}
+
+func TestQuerySetHeaders(t *testing.T) {
+	const (
+		page1 = "<http://example.com/items?page=1&per_page=10&q=go>"
+		page2 = "<http://example.com/items?page=2&per_page=10&q=go>"
+		page3 = "<http://example.com/items?page=3&per_page=10&q=go>"
+		page4 = "<http://example.com/items?page=4&per_page=10&q=go>"
+	)
+
+	tests := []struct {
+		query   string
+		dataLen int
+		link    string
+		total   string
+	}{
+		{"q=go&per_page=10", 35, page1 + `; rel="first", ` + page2 + `; rel="next", ` + page4 + `; rel="last"`, "35"},
+		{"q=go&page=2&per_page=10", 35, page1 + `; rel="first", ` + page1 + `; rel="prev", ` + page3 + `; rel="next", ` + page4 + `; rel="last"`, "35"},
+		{"q=go&page=4&per_page=10", 35, page1 + `; rel="first", ` + page3 + `; rel="prev", ` + page4 + `; rel="last"`, "35"},
+		{"q=go&page=2&per_page=10", 0, page1 + `; rel="first", ` + page1 + `; rel="last"`, "0"},
+		{"q=go&offset=5&limit=10", 12, `<http://example.com/items?limit=10&offset=0&q=go>; rel="first", ` +
+			`<http://example.com/items?limit=10&offset=0&q=go>; rel="prev", ` +
+			`<http://example.com/items?limit=10&offset=5&q=go>; rel="last"`, "12"},
+	}
+
+	for _, test := range tests {
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			pg, err := DefaultQuery.FromRequest(r, test.dataLen)
+			if err != nil {
+				http.Error(w, err.Error(), err.(*QueryError).StatusCode())
+				return
+			}
+
+			base := *r.URL
+			base.Scheme, base.Host = "http", "example.com"
+			DefaultQuery.SetHeaders(w.Header(), &base, pg)
+		})
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest("GET", "/items?"+test.query, nil))
+
+		if got, want := rec.Code, 200; got != want {
+			t.Errorf("GET %q; status = %v; want %v", test.query, got, want)
+		}
+
+		if got, want := rec.Header().Get("Link"), test.link; got != want {
+			t.Errorf("GET %q; Link = %q; want %q", test.query, got, want)
+		}
+
+		if got, want := rec.Header().Get("X-Total-Count"), test.total; got != want {
+			t.Errorf("GET %q; X-Total-Count = %q; want %q", test.query, got, want)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package pager
+
+import (
+	"iter"
+)
+
+// Row is a shown row.
+type Row struct {
+	// Index is the index within the data.
+	Index int
+
+	// Pos is the position within the shown rows.
+	Pos int
+
+	// Selected is true for the selected row.
+	Selected bool
+
+	// Marked is true for rows with a mark.
+	Marked bool
+}
+
+// Page is a page of the data, data[From:To].
+type Page struct {
+	// Number is the number of the page, starting with 0.
+	Number   int
+	From, To int
+
+	// Continued is true, if the page continues the group of the previous page, see Groups.
+	Continued bool
+}
+
+// Rows iterates over the shown rows, keyed by their index within the data.
+func (s State) Rows() iter.Seq2[int, Row] {
+	from, to, selected := s.Indexes()
+
+	return func(yield func(int, Row) bool) {
+		if from == -1 {
+			return
+		}
+
+		for i := from; i < to; i++ {
+			pos := i - from
+			row := Row{Index: i, Pos: pos, Selected: pos == selected, Marked: s.marked(i)}
+			if !yield(i, row) {
+				return
+			}
+		}
+	}
+}
+
+// Pages iterates over all pages of the data.
+// The pages of grouped items may be shorter than the height, see Groups.
+func (s State) Pages() iter.Seq[Page] {
+	var starts []int
+	if len(s.GroupStarts) > 0 {
+		starts = s.PageStarts
+	}
+
+	return func(yield func(Page) bool) {
+		for n, from := 0, 0; from < s.Len; n++ {
+			to := min(from+s.Height, s.Len)
+			if starts != nil {
+				to = s.Len
+				if n+1 < len(starts) {
+					to = starts[n+1]
+				}
+			}
+
+			if !yield(Page{Number: n, From: from, To: to, Continued: s.continued(from)}) {
+				return
+			}
+			from = to
+		}
+	}
+}
+
+// Page returns the page of the selected item (or of the first shown item,
+// if there is no selection) and the number of pages.
+func (s State) Page() (current Page, pages int) {
+	if s.Len == 0 {
+		return
+	}
+
+	i := s.Selected
+	if s.NoSelection {
+		i = s.Offset
+	}
+
+	n, from, to := s.pageOf(i)
+	current = Page{Number: n, From: from, To: to, Continued: s.continued(from)}
+
+	if len(s.GroupStarts) > 0 {
+		return current, len(s.PageStarts)
+	}
+	return current, (s.Len + s.Height - 1) / s.Height
+}
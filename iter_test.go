@@ -0,0 +1,103 @@
+package pager
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRows(t *testing.T) {
+	tests := []struct {
+		opts []Option
+		rows []Row
+	}{
+		{[]Option{PreSelect(4)}, []Row{{3, 0, false, false}, {4, 1, true, false}, {5, 2, false, false}}},
+		{[]Option{PreSelect(9)}, []Row{{9, 0, true, false}}},
+		{[]Option{Top(), PreSelect(4)}, []Row{{4, 0, true, false}, {5, 1, false, false}, {6, 2, false, false}}},
+		{[]Option{Bottom(), PreSelect(4)}, []Row{{2, 0, false, false}, {3, 1, false, false}, {4, 2, true, false}}},
+	}
+
+	for _, test := range tests {
+		pg := New(3, len(data), test.opts...)
+
+		var rows []Row
+		for i, row := range pg.State().Rows() {
+			if i != row.Index {
+				t.Errorf("key %v != row.Index %v", i, row.Index)
+			}
+			rows = append(rows, row)
+		}
+
+		if got, want := rows, test.rows; !reflect.DeepEqual(got, want) {
+			t.Errorf("Rows() = %v; want %v", got, want)
+		}
+	}
+}
+
+func TestRowsEmpty(t *testing.T) {
+	for range New(3, 0).State().Rows() {
+		t.Errorf("Rows() of empty data is not empty")
+	}
+}
+
+func TestPages(t *testing.T) {
+	tests := []struct {
+		height, dataLen int
+		pages           []Page
+	}{
+		{3, 10, []Page{{0, 0, 3, false}, {1, 3, 6, false}, {2, 6, 9, false}, {3, 9, 10, false}}},
+		{5, 10, []Page{{0, 0, 5, false}, {1, 5, 10, false}}},
+		{20, 10, []Page{{0, 0, 10, false}}},
+		{3, 0, nil},
+	}
+
+	for _, test := range tests {
+		var pages []Page
+		for page := range New(test.height, test.dataLen).State().Pages() {
+			pages = append(pages, page)
+		}
+
+		if got, want := pages, test.pages; !reflect.DeepEqual(got, want) {
+			t.Errorf("New(%v, %v).State().Pages() = %v; want %v", test.height, test.dataLen, got, want)
+		}
+	}
+
+	for page := range New(3, 10).State().Pages() {
+		if page.Number == 1 {
+			break
+		}
+	}
+}
+
+func TestPage(t *testing.T) {
+	tests := []struct {
+		opts    []Option
+		move    func(pg Dispatcher)
+		current Page
+		pages   int
+	}{
+		{nil, func(pg Dispatcher) {}, Page{0, 0, 3, false}, 4},
+		{nil, func(pg Dispatcher) { Select(pg, 7) }, Page{2, 6, 9, false}, 4},
+		{nil, func(pg Dispatcher) { Last(pg) }, Page{3, 9, 10, false}, 4},
+		{[]Option{Top()}, func(pg Dispatcher) { Select(pg, 4) }, Page{1, 3, 6, false}, 4},
+		{[]Option{NoSelection()}, func(pg Dispatcher) { Select(pg, 4) }, Page{1, 3, 6, false}, 4},
+	}
+
+	for i, test := range tests {
+		pg := New(3, len(data), test.opts...)
+		test.move(pg)
+
+		current, pages := pg.State().Page()
+
+		if got, want := current, test.current; got != want {
+			t.Errorf("[%v] Page() = %v; want %v", i, got, want)
+		}
+
+		if got, want := pages, test.pages; got != want {
+			t.Errorf("[%v] pages = %v; want %v", i, got, want)
+		}
+	}
+
+	if current, pages := New(3, 0).State().Page(); current != (Page{}) || pages != 0 {
+		t.Errorf("empty; Page() = %v, %v; want %v, 0", current, pages, Page{})
+	}
+}
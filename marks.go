@@ -0,0 +1,157 @@
+package pager
+
+import (
+	"slices"
+	"strings"
+)
+
+// Mark is a named mark on an item, like the marks of vim and less.
+type Mark struct {
+	Name string `json:"name"`
+
+	// Index is the index of the marked item within the data.
+	Index int `json:"index"`
+
+	// Key is the key of the marked item, if the MarkKeys option is used.
+	Key string `json:"key,omitempty"`
+}
+
+// MarkKeys makes the marks follow the keys of their items, when the data
+// is changed via SetLen or Append. key returns the key of the item with the given index.
+// Marks whose keys are no longer found are deleted.
+// Without this option, marks follow their items via Insert and Delete.
+func MarkKeys(key func(index int) string) Option {
+	return func(pg *pager) {
+		pg.markKey = key
+	}
+}
+
+// Mark returns the index of the item with the given mark.
+// A keyed mark beyond the length is not found, until the pager resolves it (see MarkKeys).
+func (s State) Mark(name string) (index int, ok bool) {
+	i, found := s.findMark(name)
+	if !found || s.Marks[i].Index >= s.Len {
+		return -1, false
+	}
+	return s.Marks[i].Index, true
+}
+
+// marked returns wether the item with the given index has a mark.
+func (s State) marked(index int) bool {
+	for _, m := range s.Marks {
+		if m.Index == index {
+			return true
+		}
+	}
+	return false
+}
+
+func (s State) findMark(name string) (int, bool) {
+	return slices.BinarySearchFunc(s.Marks, name, func(m Mark, name string) int {
+		return strings.Compare(m.Name, name)
+	})
+}
+
+// setMark sets the mark to the selected item, or to the first shown item
+// if there is no selection (see NoSelection).
+func (s State) setMark(name string) State {
+	index := s.Selected
+	if s.NoSelection && s.Len > 0 {
+		index = s.Offset
+	}
+	if index < 0 || index > s.Len-1 {
+		return s
+	}
+
+	m := Mark{Name: name, Index: index}
+	i, found := s.findMark(name)
+	if found {
+		s.Marks = slices.Clone(s.Marks)
+		s.Marks[i] = m
+	} else {
+		s.Marks = slices.Insert(slices.Clone(s.Marks), i, m)
+	}
+	return s
+}
+
+func (s State) deleteMark(name string) State {
+	if i, found := s.findMark(name); found {
+		s.Marks = slices.Delete(slices.Clone(s.Marks), i, i+1)
+	}
+	return s
+}
+
+// shiftMarks returns a copy of the marks with new indexes, deleting
+// the marks for which fn returns false.
+func (s State) shiftMarks(fn func(i int) (int, bool)) []Mark {
+	if len(s.Marks) == 0 {
+		return s.Marks
+	}
+
+	marks := make([]Mark, 0, len(s.Marks))
+	for _, m := range s.Marks {
+		if i, ok := fn(m.Index); ok {
+			m.Index = i
+			marks = append(marks, m)
+		}
+	}
+	return marks
+}
+
+// keyMarks sets the keys of the marks without keys.
+func (s State) keyMarks(key func(int) string) State {
+	s.Marks = s.shiftMarks(func(i int) (int, bool) { return i, true })
+	for i, m := range s.Marks {
+		if m.Key == "" {
+			s.Marks[i].Key = key(m.Index)
+		}
+	}
+	return s
+}
+
+// resolveMarks moves the marks to the current indexes of their keys.
+func (s State) resolveMarks(key func(int) string) State {
+	if len(s.Marks) == 0 {
+		return s
+	}
+
+	var index map[string]int
+
+	s.Marks = s.shiftMarks(func(i int) (int, bool) { return i, true })
+	marks := s.Marks[:0]
+	for _, m := range s.Marks {
+		if m.Index >= s.Len || key(m.Index) != m.Key {
+			if index == nil {
+				index = make(map[string]int, s.Len)
+				for i := s.Len - 1; i >= 0; i-- {
+					index[key(i)] = i
+				}
+			}
+
+			i, ok := index[m.Key]
+			if !ok {
+				continue
+			}
+			m.Index = i
+		}
+		marks = append(marks, m)
+	}
+	s.Marks = marks
+	return s
+}
+
+// SetMark sets the mark of the given name to the selected item.
+func SetMark(pg Dispatcher, name string) {
+	pg.Dispatch(ActSetMark(name))
+}
+
+// JumpToMark selects the item of the mark with the given name.
+// Returns wether the selected item has changed.
+func JumpToMark(pg Dispatcher, name string) (changed bool) {
+	return pg.Dispatch(ActJumpToMark(name)).Changed()
+}
+
+// DeleteMark deletes the mark of the given name.
+func DeleteMark(pg Dispatcher, name string) {
+	pg.Dispatch(ActDeleteMark(name))
+}
@@ -0,0 +1,159 @@
+package pager
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestMarks(t *testing.T) {
+	pg := New(3, len(data))
+
+	Select(pg, 2)
+	SetMark(pg, "b")
+	Select(pg, 7)
+	SetMark(pg, "a")
+	Select(pg, 4)
+	SetMark(pg, "c")
+
+	if got, want := pg.State().Marks, []Mark{{Name: "a", Index: 7}, {Name: "b", Index: 2}, {Name: "c", Index: 4}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Marks = %v; want %v", got, want)
+	}
+
+	if !JumpToMark(pg, "a") {
+		t.Errorf("JumpToMark(a) = false; want true")
+	}
+
+	if got, want := selectedIndex(pg), 7; got != want {
+		t.Errorf("after JumpToMark(a); selected = %v; want %v", got, want)
+	}
+
+	if JumpToMark(pg, "x") {
+		t.Errorf("JumpToMark(x) = true; want false")
+	}
+
+	DeleteMark(pg, "c")
+
+	var marked []int
+	Select(pg, 1)
+	for i, row := range pg.State().Rows() {
+		if row.Marked {
+			marked = append(marked, i)
+		}
+	}
+
+	if got, want := marked, []int{2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("marked rows = %v; want %v", got, want)
+	}
+}
+
+func TestMarksInsertDelete(t *testing.T) {
+	pg := New(3, len(data))
+
+	for i, name := range []string{"a", "b", "c", "d"} {
+		Select(pg, i*3)
+		SetMark(pg, name)
+	}
+
+	Select(pg, 4)
+
+	Insert(pg, 2, 2)
+	Delete(pg, 7, 3)
+
+	if got, want := pg.State().Marks, []Mark{{Name: "a", Index: 0}, {Name: "b", Index: 5}, {Name: "d", Index: 8}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Marks = %v; want %v", got, want)
+	}
+
+	if got, want := selectedIndex(pg), 6; got != want {
+		t.Errorf("selected = %v; want %v", got, want)
+	}
+
+	SetLen(pg, 8)
+
+	if got, want := pg.State().Marks, []Mark{{Name: "a", Index: 0}, {Name: "b", Index: 5}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("after SetLen; Marks = %v; want %v", got, want)
+	}
+}
+
+func TestMarkKeys(t *testing.T) {
+	items := []string{"a", "b", "c", "d", "e"}
+	pg := New(3, len(items), MarkKeys(func(i int) string { return items[i] }))
+
+	Select(pg, 3)
+	SetMark(pg, "x")
+
+	items = []string{"z", "d", "e"}
+	SetLen(pg, len(items))
+
+	if got, want := pg.State().Marks, []Mark{{Name: "x", Index: 1, Key: "d"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Marks = %v; want %v", got, want)
+	}
+
+	items = []string{"y", "z"}
+	SetLen(pg, len(items))
+
+	if got := pg.State().Marks; len(got) != 0 {
+		t.Errorf("Marks = %v; want none", got)
+	}
+}
+
+func TestMarkKeysReduce(t *testing.T) {
+	items := []string{"a", "b", "c", "d", "e"}
+	pg := New(3, len(items), MarkKeys(func(i int) string { return items[i] }))
+
+	Select(pg, 4)
+	SetMark(pg, "x")
+
+	state, _ := Reduce(pg.State(), ActSetLen(3))
+
+	if i, ok := state.Mark("x"); ok {
+		t.Errorf("Mark(x) = %v; want not found", i)
+	}
+
+	if state, _ = Reduce(state, ActJumpToMark("x")); state.Selected != 2 {
+		t.Errorf("ActJumpToMark(x); Selected = %v; want 2", state.Selected)
+	}
+}
+
+func TestMarksState(t *testing.T) {
+	pg := New(3, len(data))
+	Select(pg, 5)
+	SetMark(pg, "a")
+
+	state := pg.State()
+	Select(pg, 6)
+	SetMark(pg, "a")
+
+	if got, want := state.Marks, []Mark{{Name: "a", Index: 5}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("the marks of the former state have been modified: %v", got)
+	}
+
+	b, err := json.Marshal(pg.State())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded State
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := decoded, pg.State(); !reflect.DeepEqual(got, want) {
+		t.Errorf("decoded state = %+v; want %+v", got, want)
+	}
+
+	if got, want := string(b), `{"height":3,"len":10,"selected":6,"style":0,"offset":6,"requested":-1,"marks":[{"name":"a","index":6}]}`; got != want {
+		t.Errorf("json = %s; want %s", got, want)
+	}
+
+	restored := New(0, 0, FromState(decoded))
+	First(restored)
+
+	if !JumpToMark(restored, "a") {
+		t.Errorf("restored; JumpToMark(a) = false; want true")
+	}
+
+	if got, want := selectedIndex(restored), 6; got != want {
+		t.Errorf("restored; after JumpToMark(a); selected = %v; want %v", got, want)
+	}
+}
@@ -0,0 +1,127 @@
+package pager
+
+import (
+	"time"
+)
+
+// HitTest returns the index of the item that is shown in the given row.
+// row is the position within the scrolling rows, without the rows of Pinned.
+// ok is false, if no item is shown in the row.
+func HitTest(pg Pager, row int) (index int, ok bool) {
+	from, to, _ := pg.Indexes()
+	if from == -1 || row < 0 || from+row >= to {
+		return -1, false
+	}
+	return from + row, true
+}
+
+// Mouse handles the mouse events for a pager.
+// The zero value scrolls 3 lines per wheel tick without acceleration
+// and detects double clicks within 500 milliseconds.
+type Mouse struct {
+	// WheelLines is the number of lines that a wheel tick scrolls. Zero means 3.
+	WheelLines int
+
+	// Acceleration is the time within which a wheel tick in the same direction
+	// accelerates the scrolling by WheelLines, up to MaxAcceleration times
+	// the lines of a single tick. Zero means no acceleration.
+	Acceleration time.Duration
+
+	// MaxAcceleration is the maximum factor of the acceleration. Zero means 5.
+	MaxAcceleration int
+
+	// DoubleClick is the maximum time between the clicks of a double click. Zero means 500 milliseconds.
+	DoubleClick time.Duration
+
+	// Now returns the current time. If it is nil, time.Now is used.
+	Now func() time.Time
+
+	lastClick time.Time
+	lastIndex int
+	lastWheel time.Time
+	wheelDir  int
+	wheelRun  int
+}
+
+// Click selects the item that is shown in the given row (see HitTest).
+// double is true, if the same item has been clicked before within the DoubleClick time.
+// The click after a double click starts a new one.
+// index is -1 and nothing is selected, if no item is shown in the row.
+// Without a selection (see NoSelection) only the index is returned.
+func (m *Mouse) Click(pg Dispatcher, row int) (index int, double bool) {
+	index, ok := HitTest(pg, row)
+	if !ok {
+		m.lastClick = time.Time{}
+		return -1, false
+	}
+
+	if !pg.State().NoSelection {
+		Select(pg, index)
+	}
+
+	now := m.now()
+	double = !m.lastClick.IsZero() && m.lastIndex == index && now.Sub(m.lastClick) <= m.doubleClick()
+
+	if double {
+		m.lastClick = time.Time{}
+	} else {
+		m.lastClick, m.lastIndex = now, index
+	}
+	return index, double
+}
+
+// Wheel scrolls the viewport by the given number of wheel ticks,
+// down for positive and up for negative ticks (see ScrollDown and ScrollUp).
+// Returns wether the viewport has moved.
+func (m *Mouse) Wheel(pg Dispatcher, ticks int) (scrolled bool) {
+	if ticks == 0 {
+		return false
+	}
+
+	dir := 1
+	if ticks < 0 {
+		dir, ticks = -1, -ticks
+	}
+
+	now := m.now()
+	if m.Acceleration > 0 && dir == m.wheelDir && now.Sub(m.lastWheel) <= m.Acceleration {
+		m.wheelRun = min(m.wheelRun+1, m.maxAcceleration())
+	} else {
+		m.wheelRun = 1
+	}
+	m.lastWheel, m.wheelDir = now, dir
+
+	lines := ticks * m.wheelLines() * m.wheelRun
+	if dir < 0 {
+		return ScrollUp(pg, lines)
+	}
+	return ScrollDown(pg, lines)
+}
+
+func (m *Mouse) wheelLines() int {
+	if m.WheelLines > 0 {
+		return m.WheelLines
+	}
+	return 3
+}
+
+func (m *Mouse) maxAcceleration() int {
+	if m.MaxAcceleration > 0 {
+		return m.MaxAcceleration
+	}
+	return 5
+}
+
+func (m *Mouse) doubleClick() time.Duration {
+	if m.DoubleClick > 0 {
+		return m.DoubleClick
+	}
+	return 500 * time.Millisecond
+}
+
+func (m *Mouse) now() time.Time {
+	if m.Now == nil {
+		return time.Now()
+	}
+	return m.Now()
+}
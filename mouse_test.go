@@ -0,0 +1,155 @@
+package pager
+
+import (
+	"testing"
+	"time"
+)
+
+// clock is a fake clock for tests.
+type clock struct {
+	t time.Time
+}
+
+func (c *clock) Now() time.Time {
+	return c.t
+}
+
+func (c *clock) Advance(d time.Duration) {
+	c.t = c.t.Add(d)
+}
+
+func TestHitTest(t *testing.T) {
+	pg := New(3, len(data))
+	Last(pg)
+
+	tests := []struct {
+		row   int
+		index int
+		ok    bool
+	}{
+		{0, 9, true},
+		{1, -1, false},
+		{-1, -1, false},
+	}
+
+	for _, test := range tests {
+		index, ok := HitTest(pg, test.row)
+
+		if got, want := index, test.index; got != want {
+			t.Errorf("HitTest(%v) = %v; want %v", test.row, got, want)
+		}
+
+		if got, want := ok, test.ok; got != want {
+			t.Errorf("HitTest(%v); ok = %v; want %v", test.row, got, want)
+		}
+	}
+
+	if _, ok := HitTest(New(3, 0), 0); ok {
+		t.Errorf("HitTest(0) on empty data; ok = true; want false")
+	}
+}
+
+func TestMouseClick(t *testing.T) {
+	c := &clock{t: time.Unix(0, 0)}
+	m := &Mouse{Now: c.Now}
+	pg := New(3, len(data))
+	pg.PageDown()
+
+	tests := []struct {
+		wait   time.Duration
+		row    int
+		index  int
+		double bool
+	}{
+		{0, 1, 4, false},
+		{100 * time.Millisecond, 1, 4, true},
+		{100 * time.Millisecond, 1, 4, false},
+		{600 * time.Millisecond, 1, 4, false},
+		{100 * time.Millisecond, 2, 5, false},
+		{100 * time.Millisecond, 7, -1, false},
+		{100 * time.Millisecond, 2, 5, false},
+		{500 * time.Millisecond, 2, 5, true},
+	}
+
+	for i, test := range tests {
+		c.Advance(test.wait)
+		index, double := m.Click(pg, test.row)
+
+		if got, want := index, test.index; got != want {
+			t.Errorf("[%v] Click(%v) = %v; want %v", i, test.row, got, want)
+		}
+
+		if got, want := double, test.double; got != want {
+			t.Errorf("[%v] Click(%v); double = %v; want %v", i, test.row, got, want)
+		}
+
+		if test.index != -1 {
+			if got, want := selectedIndex(pg), test.index; got != want {
+				t.Errorf("[%v] Click(%v); selected = %v; want %v", i, test.row, got, want)
+			}
+		}
+	}
+}
+
+func TestMouseClickNoSelection(t *testing.T) {
+	m := &Mouse{}
+	pg := New(3, 100, NoSelection())
+	ScrollDown(pg, 5)
+
+	index, double := m.Click(pg, 2)
+
+	if index != 7 || double {
+		t.Errorf("Click(2) = %v, %v; want 7, false", index, double)
+	}
+
+	if got, want := pg.State().Offset, 5; got != want {
+		t.Errorf("Click(2); offset = %v; want %v", got, want)
+	}
+
+	if _, _, selected := pg.Indexes(); selected != -1 {
+		t.Errorf("Click(2); selected = %v; want -1", selected)
+	}
+}
+
+func TestMouseWheel(t *testing.T) {
+	c := &clock{t: time.Unix(0, 0)}
+	m := &Mouse{WheelLines: 1, Acceleration: 50 * time.Millisecond, MaxAcceleration: 3, Now: c.Now}
+	pg := New(3, 100, NoSelection())
+
+	tests := []struct {
+		wait  time.Duration
+		ticks int
+		from  int
+	}{
+		{0, 1, 1},
+		{10 * time.Millisecond, 1, 3},
+		{10 * time.Millisecond, 1, 6},
+		{10 * time.Millisecond, 1, 9},
+		{10 * time.Millisecond, -1, 8},
+		{100 * time.Millisecond, -2, 6},
+		{10 * time.Millisecond, -1, 4},
+		{100 * time.Millisecond, 2, 6},
+	}
+
+	for i, test := range tests {
+		c.Advance(test.wait)
+		m.Wheel(pg, test.ticks)
+
+		if from, _, _ := pg.Indexes(); from != test.from {
+			t.Errorf("[%v] Wheel(%v); from = %v; want %v", i, test.ticks, from, test.from)
+		}
+	}
+
+	if (&Mouse{}).Wheel(pg, 0) {
+		t.Errorf("Wheel(0) = true; want false")
+	}
+
+	pg = New(3, len(data))
+	if got, want := (&Mouse{}).Wheel(pg, 1), true; got != want {
+		t.Errorf("Wheel(1) = %v; want %v", got, want)
+	}
+
+	if got, want := selectedIndex(pg), 3; got != want {
+		t.Errorf("Wheel(1); selected = %v; want %v", got, want)
+	}
+}
@@ -6,67 +6,101 @@ type Option func(*pager)
 // PreSelect the given index in the data slice
 func PreSelect(index uint) Option {
 	return func(pg *pager) {
-		pg.selected = int(index)
+		pg.state.Selected = int(index)
+	}
+}
+
+// Stream treats the data as the items of a stream that have been loaded so far,
+// while the total length is not known yet. Whenever a move shows the last loaded
+// item or fails at the end, needMore is called with the number of loaded items.
+// Once the loader has loaded more items, they are added via Append.
+// When the end of the stream is reached, Complete must be called.
+// Each length is only requested once, unless a move fails at the end.
+func Stream(needMore func(loaded int)) Option {
+	return func(pg *pager) {
+		pg.state.Stream = true
+		pg.needMore = needMore
 	}
 }
 
 // FixPage always keeps the same pages (default)
 func FixPage() Option {
 	return func(pg *pager) {
-
-		pg.style = func(p *pager) (from, to, selected int) {
-
-			page := p.currentPage()
-
-			if page > 0 {
-				from = page * p.height
-			}
-
-			to = from + p.height
-			if p.dataLen < to {
-				to = p.dataLen
-			}
-
-			return from, to, p.selected - from
-		}
+		pg.state.Style = StyleFixPage
 	}
 }
 
 // Top keeps the selected line at the top
 func Top() Option {
 	return func(pg *pager) {
+		pg.state.Style = StyleTop
+	}
+}
 
-		pg.style = func(p *pager) (from, to, selected int) {
-
-			from = p.selected
-			to = p.selected + p.height
+// Bottom keeps the selected line at the bottom
+func Bottom() Option {
+	return func(pg *pager) {
+		pg.state.Style = StyleBottom
+	}
+}
 
-			if p.dataLen < to {
-				to = p.dataLen
-			}
+// NoSelection shows the data without a selection, like less does for files.
+// The moves scroll the viewport and Indexes always returns -1 as selected index.
+// The last page is always filled. PreSelect sets the first shown item.
+func NoSelection() Option {
+	return func(pg *pager) {
+		pg.state.NoSelection = true
+	}
+}
 
-			return from, to, 0
-		}
+// PageOverlap keeps the given number of rows of the previous page visible
+// on PageDown and PageUp, like the window of less does. The viewport and
+// the selection are moved by the height minus the overlap.
+func PageOverlap(rows uint) Option {
+	return func(pg *pager) {
+		pg.state.Overlap = int(rows)
 	}
 }
 
-// Bottom keeps the selected line at the bottom
-func Bottom() Option {
+// PageAdvance lets PageDown and PageUp move the viewport and the selection by
+// the given fraction of the height, e.g. 0.8 for 80% of the screen.
+// It takes precedence over PageOverlap.
+func PageAdvance(fraction float64) Option {
 	return func(pg *pager) {
+		pg.state.Advance = fraction
+	}
+}
 
-		pg.style = func(p *pager) (from, to, selected int) {
+// PageCursor sets the placement of the selection on PageDown and PageUp,
+// see CursorMode. Except for CursorDefault, the viewport is moved by a page
+// (see PageOverlap) and the selection is placed within the new viewport.
+// Since StyleTop and StyleBottom keep the selection on a fixed row, they move
+// the selection by a page instead; only CursorEdge first selects the edge of the viewport.
+// So with StyleTop, the other modes behave the same. With StyleBottom, they place the
+// selection, when PageUp reaches the first page, where the row of the selection is free.
+func PageCursor(mode CursorMode) Option {
+	return func(pg *pager) {
+		pg.state.Cursor = mode
+	}
+}
 
-			if p.selected < p.height {
-				to = p.dataLen
-				if to > p.height {
-					to = p.height
-				}
-				return 0, to, p.selected
-			}
+// ScrollMargin keeps the given number of rows between the selection and
+// the edges of the viewport, when the selection is dragged along by
+// ScrollDown and ScrollUp
+func ScrollMargin(rows uint) Option {
+	return func(pg *pager) {
+		pg.state.Margin = int(rows)
+	}
+}
 
-			to = p.selected + 1
-			from = to - p.height
-			return from, to, p.selected - from
-		}
+// FromState restores the given state, e.g. a state that has been kept for undo
+// or decoded from JSON. The height and the length given to New are ignored then,
+// and so are the options before FromState that change the state.
+// The callbacks of Stream, Groups and MarkKeys are not part of the state,
+// so they have to be given again.
+func FromState(s State) Option {
+	return func(pg *pager) {
+		pg.state = s
+		pg.restored = true
 	}
 }
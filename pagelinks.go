@@ -0,0 +1,159 @@
+package pager
+
+import (
+	"bytes"
+	"html/template"
+)
+
+// PageLinkKind is the kind of a PageLink.
+type PageLinkKind int
+
+const (
+	// PageLinkPage is a link to a page.
+	PageLinkPage PageLinkKind = iota
+
+	// PageLinkEllipsis stands for omitted pages.
+	PageLinkEllipsis
+
+	// PageLinkPrev is the link to the previous page.
+	PageLinkPrev
+
+	// PageLinkNext is the link to the next page.
+	PageLinkNext
+)
+
+// PageLink is an entry of a pagination bar.
+type PageLink struct {
+	Kind PageLinkKind
+
+	// Page is the number of the page (starting with 1) the entry links to.
+	// It is 0 for ellipses and disabled prev and next links.
+	Page int
+
+	// Current is true for the entry of the current page.
+	Current bool
+
+	// Disabled is true for prev and next links without a target.
+	Disabled bool
+}
+
+// IsEllipsis returns wether the entry stands for omitted pages.
+func (l PageLink) IsEllipsis() bool {
+	return l.Kind == PageLinkEllipsis
+}
+
+// IsPrev returns wether the entry is the link to the previous page.
+func (l PageLink) IsPrev() bool {
+	return l.Kind == PageLinkPrev
+}
+
+// IsNext returns wether the entry is the link to the next page.
+func (l PageLink) IsNext() bool {
+	return l.Kind == PageLinkNext
+}
+
+// PageLinks returns the entries of a pagination bar for the pages of
+// the given pager, like
+//
+//	‹ 1 … 4 5 [6] 7 8 … 20 ›
+//
+// The bar starts with the prev and ends with the next link. The first and last page
+// are always shown, together with the given number of neighbors on each side of the
+// current page. Omitted pages are replaced by an ellipsis, unless it would stand
+// for a single page. Near the ends, the window is shifted, so that the number of
+// entries only depends on the number of pages and neighbors.
+//
+// The pages and the current page are the ones of Page, so that the pages of
+// grouped items are respected (see Groups). If there is no data,
+// there is a single page. If the pager is beyond its data, there is no current page.
+func PageLinks(pg Dispatcher, neighbors int) []PageLink {
+	s := pg.State()
+	from, _, _ := s.Indexes()
+	cur, pages := s.Page()
+	if pages == 0 {
+		pages = 1
+	}
+
+	current, hasCurrent := cur.Number+1, true
+	if from == -1 && s.Len > 0 {
+		current, hasCurrent = pages, false
+	}
+
+	// more neighbors than pages don't change the bar, but could overflow
+	neighbors = min(max(neighbors, 0), pages)
+
+	first, last := current-neighbors, current+neighbors
+	switch {
+	case pages <= 2*neighbors+5:
+		first, last = 1, pages
+	case current <= neighbors+3:
+		first, last = 1, 2*neighbors+3
+	case current >= pages-neighbors-2:
+		first, last = pages-2*neighbors-2, pages
+	}
+
+	links := make([]PageLink, 0, min(pages, 2*neighbors+5)+2)
+
+	prev := PageLink{Kind: PageLinkPrev, Page: current - 1, Disabled: current == 1}
+	if !hasCurrent {
+		prev.Page, prev.Disabled = pages, false
+	}
+	if prev.Disabled {
+		prev.Page = 0
+	}
+	links = append(links, prev)
+
+	page := func(p int) {
+		links = append(links, PageLink{Kind: PageLinkPage, Page: p, Current: hasCurrent && p == current})
+	}
+
+	if first > 1 {
+		page(1)
+		links = append(links, PageLink{Kind: PageLinkEllipsis})
+	}
+
+	for p := first; p <= last; p++ {
+		page(p)
+	}
+
+	if last < pages {
+		links = append(links, PageLink{Kind: PageLinkEllipsis})
+		page(pages)
+	}
+
+	next := PageLink{Kind: PageLinkNext, Page: current + 1, Disabled: !hasCurrent || current == pages}
+	if next.Disabled {
+		next.Page = 0
+	}
+	links = append(links, next)
+
+	return links
+}
+
+var pageLinksTemplate = template.Must(template.New("pagelinks").Parse(
+	`<nav aria-label="Pagination"><ul>` +
+		`{{range .Links}}<li>` +
+		`{{if .IsEllipsis}}<span aria-hidden="true">…</span>` +
+		`{{else if .IsPrev}}{{if .Disabled}}<span aria-disabled="true">Previous</span>{{else}}<a href="{{call $.Href .Page}}" rel="prev">Previous</a>{{end}}` +
+		`{{else if .IsNext}}{{if .Disabled}}<span aria-disabled="true">Next</span>{{else}}<a href="{{call $.Href .Page}}" rel="next">Next</a>{{end}}` +
+		`{{else}}<a href="{{call $.Href .Page}}" aria-label="Page {{.Page}}"{{if .Current}} aria-current="page"{{end}}>{{.Page}}</a>` +
+		`{{end}}</li>{{end}}` +
+		`</ul></nav>`,
+))
+
+// PageLinksHTML renders the given entries as an accessible navigation.
+// href returns the URL of the given page.
+func PageLinksHTML(links []PageLink, href func(page int) string) (template.HTML, error) {
+	var bf bytes.Buffer
+
+	err := pageLinksTemplate.Execute(&bf, struct {
+		Links []PageLink
+		Href  func(int) string
+	}{links, href})
+
+	if err != nil {
+		return "", err
+	}
+
+	return template.HTML(bf.String()), nil
+}
@@ -0,0 +1,143 @@
+package pager
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func formatPageLinks(links []PageLink) string {
+	var parts []string
+	for _, l := range links {
+		var s string
+		switch l.Kind {
+		case PageLinkPrev:
+			s = "<"
+		case PageLinkNext:
+			s = ">"
+		case PageLinkEllipsis:
+			s = "…"
+		default:
+			s = strconv.Itoa(l.Page)
+		}
+
+		switch {
+		case l.Disabled:
+			s = "(" + s + ")"
+		case l.Current:
+			s = "[" + s + "]"
+		}
+		parts = append(parts, s)
+	}
+	return strings.Join(parts, " ")
+}
+
+func TestPageLinks(t *testing.T) {
+	tests := []struct {
+		dataLen   int
+		selected  uint
+		neighbors int
+		bar       string
+	}{
+		{200, 55, 2, "< 1 … 4 5 [6] 7 8 … 20 >"},
+		{200, 0, 2, "(<) [1] 2 3 4 5 6 7 … 20 >"},
+		{200, 45, 2, "< 1 2 3 4 [5] 6 7 … 20 >"},
+		{200, 55, 0, "< 1 … [6] … 20 >"},
+		{200, 155, 2, "< 1 … 14 15 [16] 17 18 19 20 >"},
+		{200, 199, 2, "< 1 … 14 15 16 17 18 19 [20] (>)"},
+		{90, 45, 2, "< 1 2 3 4 [5] 6 7 8 9 >"},
+		{10, 5, 2, "(<) [1] (>)"},
+		{15, 12, 2, "< 1 [2] (>)"},
+		{0, 0, 2, "(<) [1] (>)"},
+	}
+
+	for _, test := range tests {
+		pg := New(10, test.dataLen, PreSelect(test.selected))
+
+		if got, want := formatPageLinks(PageLinks(pg, test.neighbors)), test.bar; got != want {
+			t.Errorf("PageLinks(New(10, %v, PreSelect(%v)), %v) = %q; want %q", test.dataLen, test.selected, test.neighbors, got, want)
+		}
+	}
+}
+
+func TestPageLinksBeyondData(t *testing.T) {
+	pg := New(10, 30, Top(), PreSelect(50))
+
+	if got, want := formatPageLinks(PageLinks(pg, 1)), "< 1 2 3 (>)"; got != want {
+		t.Errorf("PageLinks() = %q; want %q", got, want)
+	}
+
+	if got, want := PageLinks(pg, 1)[0].Page, 3; got != want {
+		t.Errorf("prev.Page = %v; want %v", got, want)
+	}
+}
+
+func TestPageLinksGroups(t *testing.T) {
+	pg := New(3, 10, Groups(customers(2, 2, 2, 2, 2)))
+	Last(pg)
+
+	if got, want := formatPageLinks(PageLinks(pg, 1)), "< 1 2 3 4 [5] (>)"; got != want {
+		t.Errorf("PageLinks() = %q; want %q", got, want)
+	}
+}
+
+func TestPageLinksManyNeighbors(t *testing.T) {
+	pg := New(10, 40, PreSelect(15))
+
+	for _, neighbors := range []int{1 << 20, 1 << 40, int(^uint(0) >> 1)} {
+		links := PageLinks(pg, neighbors)
+
+		if got, want := formatPageLinks(links), "< 1 [2] 3 4 >"; got != want {
+			t.Errorf("PageLinks(%v) = %q; want %q", neighbors, got, want)
+		}
+
+		if got, want := cap(links), 6; got != want {
+			t.Errorf("PageLinks(%v); cap = %v; want %v", neighbors, got, want)
+		}
+	}
+}
+
+func TestPageLinkKinds(t *testing.T) {
+	tests := []struct {
+		kind                 PageLinkKind
+		ellipsis, prev, next bool
+	}{
+		{PageLinkPage, false, false, false},
+		{PageLinkEllipsis, true, false, false},
+		{PageLinkPrev, false, true, false},
+		{PageLinkNext, false, false, true},
+	}
+
+	for _, test := range tests {
+		l := PageLink{Kind: test.kind}
+
+		if l.IsEllipsis() != test.ellipsis || l.IsPrev() != test.prev || l.IsNext() != test.next {
+			t.Errorf("kind %v: IsEllipsis, IsPrev, IsNext = %v, %v, %v; want %v, %v, %v",
+				test.kind, l.IsEllipsis(), l.IsPrev(), l.IsNext(), test.ellipsis, test.prev, test.next)
+		}
+	}
+}
+
+func TestPageLinksHTML(t *testing.T) {
+	pg := New(10, 30, PreSelect(15))
+
+	html, err := PageLinksHTML(PageLinks(pg, 1), func(page int) string {
+		return "/items?page=" + strconv.Itoa(page)
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `<nav aria-label="Pagination"><ul>` +
+		`<li><a href="/items?page=1" rel="prev">Previous</a></li>` +
+		`<li><a href="/items?page=1" aria-label="Page 1">1</a></li>` +
+		`<li><a href="/items?page=2" aria-label="Page 2" aria-current="page">2</a></li>` +
+		`<li><a href="/items?page=3" aria-label="Page 3">3</a></li>` +
+		`<li><a href="/items?page=3" rel="next">Next</a></li>` +
+		`</ul></nav>`
+
+	if got := string(html); got != want {
+		t.Errorf("PageLinksHTML() = \n%s\nwant\n%s", got, want)
+	}
+}
@@ -25,80 +25,151 @@ type Pager interface {
 	Indexes() (from, to, selected int)
 }
 
+// Dispatcher is a Pager whose state is changed via actions, see Reduce.
+// The pagers of New and the wrapping pagers (e.g. Record) are Dispatchers.
+// The other operations, like Select or Resize, are functions over a Dispatcher.
+type Dispatcher interface {
+	Pager
+
+	// State returns the current state.
+	State() State
+
+	// Dispatch applies the given action (see Reduce) and returns its effect.
+	Dispatch(a Action) Effect
+}
+
 type pager struct {
-	dataLen, selected, height int
-	dataLenDivHeight          int
-	style                     func(*pager) (from, to, selected int)
+	state    State
+	needMore func(loaded int)
+	markKey  func(index int) string
+	group    func(index int) string
+	restored bool
 }
 
 // New creates a new pager.
-// Each time the height or dataLen changes, a new pager should be created.
-func New(height, dataLen int, opts ...Option) Pager {
-	p := &pager{height: height, dataLen: dataLen}
-	p.dataLenDivHeight = dataLen / height
+// Changes of the height or the length of the data are applied via Resize, SetLen and Append.
+func New(height, dataLen int, opts ...Option) Dispatcher {
+	p := &pager{state: NewState(height, dataLen, StyleFixPage)}
 
 	for _, opt := range opts {
 		opt(p)
 	}
 
-	if p.style == nil {
-		FixPage()(p)
+	if p.restored {
+		return p
 	}
 
 	if dataLen == 0 {
-		p.selected = -1
+		p.state.Selected = -1
 	}
+	p.state.Height = p.state.bodyHeight(height)
+
+	if p.group != nil {
+		p.state = p.state.regroup(p.group)
+	}
+
+	if p.state.NoSelection {
+		p.state = p.state.scrollTo(p.state.Selected)
+		p.state.Selected = -1
+		return p
+	}
+
+	p.state.Offset = p.state.offset()
 	return p
 }
 
-// Next selects the next item. Returns wether the selected item has changed.
-func (p *pager) Next() (changed bool) {
-	if p.dataLen-1 > p.selected {
-		p.selected++
-		changed = true
+// Dispatch applies the given action (see Reduce) and returns its effect.
+// If more items of a stream are needed, the callback of the Stream option is called.
+func (p *pager) Dispatch(a Action) (effect Effect) {
+	p.state, effect = Reduce(p.state, a)
+
+	if p.group != nil {
+		switch a.Op {
+		case OpSetLen, OpAppend, OpInsert, OpDelete:
+			offset := p.state.Offset
+			p.state = p.state.regroup(p.group)
+			if p.state.Style == StyleFixPage && !p.state.NoSelection {
+				p.state.Offset = p.state.offset()
+			}
+			if p.state.Offset != offset {
+				effect |= EffectScrolled
+			}
+		}
+	}
+
+	if p.markKey != nil {
+		switch a.Op {
+		case OpSetMark:
+			p.state = p.state.keyMarks(p.markKey)
+		case OpSetLen, OpAppend, OpInsert, OpDelete:
+			p.state = p.state.resolveMarks(p.markKey)
+		}
+	}
+
+	if effect.NeedMore() && p.needMore != nil {
+		p.needMore(p.state.Len)
 	}
 	return
 }
 
+// State returns the current state.
+func (p *pager) State() State {
+	return p.state
+}
+
+// Next selects the next item. Returns wether the selected item has changed.
+func (p *pager) Next() (changed bool) {
+	return p.Dispatch(ActNext()).Changed()
+}
+
 // Prev selects the previous item. Returns wether the selected item has changed.
 func (p *pager) Prev() (changed bool) {
-	if p.selected > 0 {
-		p.selected--
-		changed = true
-	}
-	return
+	return p.Dispatch(ActPrev()).Changed()
 }
 
 // PageDown selects the next page. Returns wether the selected item has changed.
 func (p *pager) PageDown() (changed bool) {
-	if p.dataLen == 0 {
-		return
-	}
+	return p.Dispatch(ActPageDown()).Changed()
+}
 
-	page := p.currentPage()
-	if page >= p.dataLenDivHeight {
-		return
-	}
+// PageUp selects the previous page. Returns wether the selected item has changed.
+func (p *pager) PageUp() (changed bool) {
+	return p.Dispatch(ActPageUp()).Changed()
+}
 
-	page++
-	p.selected = (page+1)*p.height - 1
-	changed = true
+// First selects the first item. Returns wether the selected item has changed.
+func First(pg Dispatcher) (changed bool) {
+	return pg.Dispatch(ActFirst()).Changed()
+}
 
-	if p.selected > p.dataLen-1 {
-		p.selected = p.dataLen - 1
-	}
-	return
+// Last selects the last item. Returns wether the selected item has changed.
+func Last(pg Dispatcher) (changed bool) {
+	return pg.Dispatch(ActLast()).Changed()
 }
 
-// PageUp selects the previous page. Returns wether the selected item has changed.
-func (p *pager) PageUp() (changed bool) {
-	if p.currentPage() == 0 {
-		return
-	}
+// Select selects the item with the given index within the data.
+// The index is clamped to the data. Returns wether the selected item has changed.
+func Select(pg Dispatcher, index int) (changed bool) {
+	return pg.Dispatch(ActSelect(index)).Changed()
+}
 
-	p.selected -= p.height
-	changed = true
-	return
+// ScrollDown moves the viewport n rows down without moving the selection,
+// unless it would leave the viewport. Returns wether the viewport has moved.
+func ScrollDown(pg Dispatcher, n int) (scrolled bool) {
+	return pg.Dispatch(ActScrollDown(n)).Scrolled()
+}
+
+// ScrollUp moves the viewport n rows up without moving the selection,
+// unless it would leave the viewport. Returns wether the viewport has moved.
+func ScrollUp(pg Dispatcher, n int) (scrolled bool) {
+	return pg.Dispatch(ActScrollUp(n)).Scrolled()
+}
+
+// ScrollTo selects the item with the given index and scrolls it to the given
+// alignment, without running past the ends of the data. Without selection
+// (see NoSelection), only the viewport is moved. Returns wether the viewport has moved.
+func ScrollTo(pg Dispatcher, index int, align Align) (scrolled bool) {
+	return pg.Dispatch(ActScrollTo(index, align)).Scrolled()
 }
 
 // Indexes returns the from, to and selected index.
@@ -108,18 +179,38 @@ func (p *pager) PageUp() (changed bool) {
 // If selected is -1, there is no selection.
 // If from is -1, there is no data to be shown.
 func (p *pager) Indexes() (from, to, selected int) {
-	if p.dataLen == 0 || p.selected > p.dataLen-1 {
-		return -1, -1, -1
-	}
+	return p.state.Indexes()
+}
 
-	return p.style(p)
+// Resize changes the height, including the fixed rows (see Pinned).
+func Resize(pg Dispatcher, height int) {
+	pg.Dispatch(ActResize(height))
 }
 
-func (p *pager) currentPage() (page int) {
-	if p.selected < 0 {
-		return
-	}
+// SetLen changes the length of the data. The selection is clamped to the data.
+func SetLen(pg Dispatcher, n int) {
+	pg.Dispatch(ActSetLen(n))
+}
 
-	page = p.selected / p.height
-	return
+// Append extends the data by n items, e.g. after more items of
+// a stream have been loaded.
+func Append(pg Dispatcher, n int) {
+	pg.Dispatch(ActAppend(n))
+}
+
+// Complete marks the end of a stream, so that no more items are requested.
+func Complete(pg Dispatcher) {
+	pg.Dispatch(ActComplete())
+}
+
+// Insert notifies the pager that n items have been inserted at the given index.
+// The selection and the marks follow their items.
+func Insert(pg Dispatcher, at, n int) {
+	pg.Dispatch(ActInsert(at, n))
+}
+
+// Delete notifies the pager that n items have been deleted at the given index.
+// The selection and the marks follow their items, marks of deleted items are deleted.
+func Delete(pg Dispatcher, at, n int) {
+	pg.Dispatch(ActDelete(at, n))
 }
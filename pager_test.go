@@ -1,6 +1,7 @@
 package pager
 
 import (
+	"reflect"
 	"testing"
 )
 
@@ -46,9 +47,9 @@ func TestCurrentPage(t *testing.T) {
 
 	for _, test := range tests {
 		pager := newPager(test.height)
-		pager.selected = test.selected
+		pager.state.Selected = test.selected
 
-		page := pager.currentPage()
+		page := pager.state.currentPage()
 
 		if got, want := page, test.page; got != want {
 			t.Errorf("height: %v selected: %v; page = %#v; want %#v", test.height, test.selected, got, want)
@@ -58,6 +59,75 @@ func TestCurrentPage(t *testing.T) {
 
 }
 
+func TestPageDownLastPage(t *testing.T) {
+	tests := []struct {
+		style    Option
+		height   int
+		dataLen  int
+		selected uint
+		from     int
+	}{
+		{FixPage(), 3, 11, 9, 9},
+		{FixPage(), 3, 11, 10, 9},
+		{FixPage(), 3, 9, 6, 6},
+		{FixPage(), 3, 2, 0, 0},
+		{Top(), 3, 2, 0, 0},
+		{Top(), 3, 11, 9, 9},
+		{Top(), 3, 11, 10, 10},
+		{Bottom(), 3, 2, 0, 0},
+		{Bottom(), 3, 11, 9, 7},
+		{Bottom(), 3, 11, 10, 8},
+	}
+
+	for i, test := range tests {
+		pg := New(test.height, test.dataLen, test.style, PreSelect(test.selected))
+
+		if pg.PageDown() {
+			t.Errorf("[%v] PageDown() = true; want false", i)
+		}
+
+		from, _, selected := pg.Indexes()
+
+		if got, want := from+selected, int(test.selected); got != want {
+			t.Errorf("[%v] PageDown(); selected = %v; want %v", i, got, want)
+		}
+
+		if got, want := from, test.from; got != want {
+			t.Errorf("[%v] PageDown(); from = %v; want %v", i, got, want)
+		}
+	}
+}
+
+func TestFromState(t *testing.T) {
+	pg := New(3, len(data), Header(1), PreSelect(4))
+	undo := pg.State()
+
+	pg.PageDown()
+	SetLen(pg, 6)
+
+	pg = New(0, 0, FromState(undo))
+
+	if got, want := pg.State(), undo; !reflect.DeepEqual(got, want) {
+		t.Errorf("State() = %+v; want %+v", got, want)
+	}
+
+	lines, selected := displayData(pg)
+
+	if got, want := lines, []string{"five", "six"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("lines = %#v; want %#v", got, want)
+	}
+
+	if got, want := selected, "five"; got != want {
+		t.Errorf("selected = %#v; want %#v", got, want)
+	}
+
+	pg.PageDown()
+
+	if _, got := displayData(pg); got != "eight" {
+		t.Errorf("PageDown(); selected = %#v; want %#v", got, "eight")
+	}
+}
+
 func TestEmpty(t *testing.T) {
 	pg := New(3, 0)
 
@@ -84,6 +154,73 @@ func TestEmpty(t *testing.T) {
 	}
 }
 
+func TestStream(t *testing.T) {
+	var requests []int
+	pg := New(3, 4, Stream(func(loaded int) { requests = append(requests, loaded) }))
+
+	if !pg.State().HasMore() {
+		t.Fatalf("HasMore() = false; want true")
+	}
+
+	pg.PageDown()
+
+	if got, want := requests, []int{4}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("after PageDown: requests = %v; want %v", got, want)
+	}
+
+	pg.Prev()
+	pg.Next()
+
+	if got, want := requests, []int{4}; !reflect.DeepEqual(got, want) {
+		t.Errorf("after Prev, Next: requests = %v; want %v", got, want)
+	}
+
+	if pg.Next() {
+		t.Errorf("Next() at the end = true; want false")
+	}
+
+	if got, want := requests, []int{4, 4}; !reflect.DeepEqual(got, want) {
+		t.Errorf("after failed Next: requests = %v; want %v", got, want)
+	}
+
+	Append(pg, 4)
+
+	from, to, selected := pg.Indexes()
+	if from != 3 || to != 6 || selected != 0 {
+		t.Errorf("after Append: from: %v, to: %v, selected: %v", from, to, selected)
+	}
+
+	pg.PageDown()
+	Complete(pg)
+	pg.PageDown()
+
+	if got, want := requests, []int{4, 4, 8}; !reflect.DeepEqual(got, want) {
+		t.Errorf("after Complete: requests = %v; want %v", got, want)
+	}
+
+	if pg.State().HasMore() {
+		t.Errorf("HasMore() = true; want false")
+	}
+}
+
+func TestStreamEmpty(t *testing.T) {
+	var requests []int
+	pg := New(3, 0, Stream(func(loaded int) { requests = append(requests, loaded) }))
+
+	pg.Next()
+
+	if got, want := requests, []int{0}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("requests = %v; want %v", got, want)
+	}
+
+	Append(pg, 2)
+
+	from, to, selected := pg.Indexes()
+	if from != 0 || to != 2 || selected != 0 {
+		t.Errorf("after Append: from: %v, to: %v, selected: %v", from, to, selected)
+	}
+}
+
 func BenchmarkNext(b *testing.B) {
 	b.StopTimer()
 
@@ -131,3 +268,278 @@ func BenchmarkPageUp(b *testing.B) {
 		pg.PageUp()
 	}
 }
+
+func TestScroll(t *testing.T) {
+	tests := []struct {
+		opts     []Option
+		moves    func(pg Dispatcher)
+		lines    []string
+		selected string
+	}{
+		{nil, func(pg Dispatcher) { Select(pg, 4); ScrollDown(pg, 2) }, []string{"six", "seven", "eight"}, "six"},
+		{nil, func(pg Dispatcher) { Select(pg, 4); ScrollUp(pg, 1) }, []string{"three", "four", "five"}, "five"},
+		{nil, func(pg Dispatcher) { Select(pg, 4); ScrollUp(pg, 1); pg.Prev() }, []string{"three", "four", "five"}, "four"},
+		{nil, func(pg Dispatcher) { Select(pg, 4); ScrollUp(pg, 1); pg.Next() }, []string{"four", "five", "six"}, "six"},
+		{nil, func(pg Dispatcher) { Last(pg); ScrollDown(pg, 1) }, []string{"ten"}, "ten"},
+		{nil, func(pg Dispatcher) { Last(pg); ScrollUp(pg, 1) }, []string{"nine", "ten"}, "ten"},
+		{nil, func(pg Dispatcher) { ScrollDown(pg, 20) }, []string{"eight", "nine", "ten"}, "eight"},
+		{[]Option{Top()}, func(pg Dispatcher) { Select(pg, 4); ScrollUp(pg, 1) }, []string{"four", "five", "six"}, "five"},
+		{[]Option{Top()}, func(pg Dispatcher) { Select(pg, 4); ScrollUp(pg, 1); pg.Next() }, []string{"six", "seven", "eight"}, "six"},
+		{[]Option{Bottom()}, func(pg Dispatcher) { Select(pg, 4); ScrollDown(pg, 1) }, []string{"four", "five", "six"}, "five"},
+		{[]Option{Bottom()}, func(pg Dispatcher) { Select(pg, 4); ScrollDown(pg, 1); pg.Prev() }, []string{"two", "three", "four"}, "four"},
+		{[]Option{ScrollMargin(1)}, func(pg Dispatcher) { ScrollDown(pg, 1) }, []string{"two", "three", "four"}, "three"},
+		{[]Option{ScrollMargin(1)}, func(pg Dispatcher) { ScrollDown(pg, 9) }, []string{"eight", "nine", "ten"}, "nine"},
+		{[]Option{ScrollMargin(1)}, func(pg Dispatcher) { Last(pg); ScrollUp(pg, 3) }, []string{"seven", "eight", "nine"}, "eight"},
+	}
+
+	for i, test := range tests {
+		pg := New(3, len(data), test.opts...)
+		test.moves(pg)
+
+		lines, selected := displayData(pg)
+
+		if got, want := lines, test.lines; !reflect.DeepEqual(got, want) {
+			t.Errorf("[%v] lines = %#v; want %#v", i, got, want)
+		}
+
+		if got, want := selected, test.selected; got != want {
+			t.Errorf("[%v] selected = %#v; want %#v", i, got, want)
+		}
+	}
+
+	pg := New(3, len(data))
+
+	if got, want := ScrollUp(pg, 1), false; got != want {
+		t.Errorf("ScrollUp(1) at the top = %v; want %v", got, want)
+	}
+
+	if got, want := ScrollDown(pg, 1), true; got != want {
+		t.Errorf("ScrollDown(1) = %v; want %v", got, want)
+	}
+}
+
+func TestNoSelection(t *testing.T) {
+	tests := []struct {
+		moves   func(pg Dispatcher)
+		changed bool
+		lines   []string
+	}{
+		{func(pg Dispatcher) {}, false, []string{"one", "two", "three"}},
+		{func(pg Dispatcher) { pg.Next() }, true, []string{"two", "three", "four"}},
+		{func(pg Dispatcher) { pg.Prev() }, false, []string{"one", "two", "three"}},
+		{func(pg Dispatcher) { pg.PageDown() }, true, []string{"four", "five", "six"}},
+		{func(pg Dispatcher) { pg.PageDown(); pg.PageDown(); pg.PageDown() }, true, []string{"eight", "nine", "ten"}},
+		{func(pg Dispatcher) { Last(pg); pg.Next() }, true, []string{"eight", "nine", "ten"}},
+		{func(pg Dispatcher) { Last(pg); pg.PageUp() }, true, []string{"five", "six", "seven"}},
+		{func(pg Dispatcher) { Select(pg, 5) }, true, []string{"six", "seven", "eight"}},
+		{func(pg Dispatcher) { Select(pg, 5); Resize(pg, 6) }, true, []string{"five", "six", "seven", "eight", "nine", "ten"}},
+		{func(pg Dispatcher) { Select(pg, 5); SetMark(pg, "a"); First(pg); JumpToMark(pg, "a") }, true, []string{"six", "seven", "eight"}},
+		{func(pg Dispatcher) { Select(pg, 5); Delete(pg, 0, 2) }, true, []string{"four", "five", "six"}},
+	}
+
+	for i, test := range tests {
+		pg := New(3, len(data), NoSelection())
+		test.moves(pg)
+
+		from, to, selected := pg.Indexes()
+
+		if got, want := data[from:to], test.lines; !reflect.DeepEqual(got, want) {
+			t.Errorf("[%v] lines = %#v; want %#v", i, got, want)
+		}
+
+		if selected != -1 {
+			t.Errorf("[%v] selected = %v; want -1", i, selected)
+		}
+
+		if got, want := pg.Prev(), test.changed; got != want {
+			t.Errorf("[%v] Prev() = %v; want %v", i, got, want)
+		}
+	}
+
+	pg := New(3, len(data), NoSelection())
+	Last(pg)
+	if pg.Next() {
+		t.Errorf("Next() at the end = true; want false")
+	}
+
+	pg = New(3, len(data), NoSelection(), PreSelect(9))
+	if from, to, _ := pg.Indexes(); from != 7 || to != 10 {
+		t.Errorf("PreSelect(9); from: %v, to: %v; want 7, 10", from, to)
+	}
+}
+
+func TestScrollTo(t *testing.T) {
+	tests := []struct {
+		opts     []Option
+		index    int
+		align    Align
+		lines    []string
+		selected string
+	}{
+		{nil, 4, AlignTop, []string{"five", "six", "seven"}, "five"},
+		{nil, 4, AlignCenter, []string{"four", "five", "six"}, "five"},
+		{nil, 4, AlignBottom, []string{"three", "four", "five"}, "five"},
+		{nil, 4, AlignNearest, []string{"three", "four", "five"}, "five"},
+		{nil, 1, AlignNearest, []string{"one", "two", "three"}, "two"},
+		{nil, 9, AlignTop, []string{"eight", "nine", "ten"}, "ten"},
+		{nil, 0, AlignBottom, []string{"one", "two", "three"}, "one"},
+		{nil, 42, AlignCenter, []string{"eight", "nine", "ten"}, "ten"},
+		{[]Option{Top()}, 4, AlignCenter, []string{"four", "five", "six"}, "five"},
+		{[]Option{Top()}, 8, AlignTop, []string{"eight", "nine", "ten"}, "nine"},
+		{[]Option{Bottom()}, 4, AlignTop, []string{"five", "six", "seven"}, "five"},
+		{[]Option{Bottom()}, 1, AlignCenter, []string{"one", "two", "three"}, "two"},
+		{[]Option{NoSelection()}, 4, AlignCenter, []string{"four", "five", "six"}, ""},
+		{[]Option{NoSelection()}, 9, AlignNearest, []string{"eight", "nine", "ten"}, ""},
+	}
+
+	for i, test := range tests {
+		pg := New(3, len(data), test.opts...)
+		ScrollTo(pg, test.index, test.align)
+
+		lines, selected := displayData(pg)
+
+		if got, want := lines, test.lines; !reflect.DeepEqual(got, want) {
+			t.Errorf("[%v] ScrollTo(%v, %v); lines = %#v; want %#v", i, test.index, test.align, got, want)
+		}
+
+		if got, want := selected, test.selected; got != want {
+			t.Errorf("[%v] ScrollTo(%v, %v); selected = %#v; want %#v", i, test.index, test.align, got, want)
+		}
+	}
+
+	pg := New(3, len(data))
+	Last(pg)
+
+	if got, want := ScrollTo(pg, 9, AlignNearest), false; got != want {
+		t.Errorf("ScrollTo(9, nearest) on the shown item = %v; want %v", got, want)
+	}
+}
+
+func TestPageOverlap(t *testing.T) {
+	tests := []struct {
+		opts     []Option
+		moves    func(pg Dispatcher)
+		lines    []string
+		selected string
+	}{
+		{[]Option{PageOverlap(1)}, func(pg Dispatcher) { pg.PageDown() }, []string{"four", "five", "six", "seven"}, "four"},
+		{[]Option{PageOverlap(1)}, func(pg Dispatcher) { pg.PageDown(); pg.PageDown() }, []string{"seven", "eight", "nine", "ten"}, "seven"},
+		{[]Option{PageOverlap(1)}, func(pg Dispatcher) { pg.PageDown(); pg.PageDown(); pg.PageDown() }, []string{"seven", "eight", "nine", "ten"}, "ten"},
+		{[]Option{PageOverlap(1)}, func(pg Dispatcher) { Last(pg); pg.PageUp() }, []string{"six", "seven", "eight", "nine"}, "seven"},
+		{[]Option{PageOverlap(1), Top()}, func(pg Dispatcher) { pg.PageDown() }, []string{"four", "five", "six", "seven"}, "four"},
+		{[]Option{PageOverlap(1), Bottom()}, func(pg Dispatcher) { pg.PageDown() }, []string{"one", "two", "three", "four"}, "four"},
+		{[]Option{PageOverlap(1), NoSelection()}, func(pg Dispatcher) { pg.PageDown() }, []string{"four", "five", "six", "seven"}, ""},
+		{[]Option{PageAdvance(0.5)}, func(pg Dispatcher) { pg.PageDown() }, []string{"three", "four", "five", "six"}, "three"},
+		{[]Option{PageAdvance(0.5), PageOverlap(3)}, func(pg Dispatcher) { pg.PageDown() }, []string{"three", "four", "five", "six"}, "three"},
+		{[]Option{PageOverlap(9)}, func(pg Dispatcher) { pg.PageDown() }, []string{"two", "three", "four", "five"}, "two"},
+	}
+
+	for i, test := range tests {
+		pg := New(4, len(data), test.opts...)
+		test.moves(pg)
+
+		lines, selected := displayData(pg)
+
+		if got, want := lines, test.lines; !reflect.DeepEqual(got, want) {
+			t.Errorf("[%v] lines = %#v; want %#v", i, got, want)
+		}
+
+		if got, want := selected, test.selected; got != want {
+			t.Errorf("[%v] selected = %#v; want %#v", i, got, want)
+		}
+	}
+}
+
+func TestPageCursor(t *testing.T) {
+	tests := []struct {
+		style    Option
+		mode     CursorMode
+		selected int
+		down     []string
+		downSel  string
+		up       []string
+		upSel    string
+	}{
+		{FixPage(), CursorKeepRow, 4, []string{"seven", "eight", "nine"}, "eight", []string{"one", "two", "three"}, "two"},
+		{FixPage(), CursorKeepRow, 8, []string{"ten"}, "ten", []string{"four", "five", "six"}, "six"},
+		{FixPage(), CursorFirstRow, 4, []string{"seven", "eight", "nine"}, "seven", []string{"one", "two", "three"}, "one"},
+		{FixPage(), CursorFirstRow, 8, []string{"ten"}, "ten", []string{"four", "five", "six"}, "four"},
+		{FixPage(), CursorLastRow, 4, []string{"seven", "eight", "nine"}, "nine", []string{"one", "two", "three"}, "three"},
+		{FixPage(), CursorLastRow, 8, []string{"ten"}, "ten", []string{"four", "five", "six"}, "six"},
+		{FixPage(), CursorEdge, 4, []string{"four", "five", "six"}, "six", []string{"four", "five", "six"}, "four"},
+		{FixPage(), CursorEdge, 8, []string{"ten"}, "ten", []string{"seven", "eight", "nine"}, "seven"},
+		{Top(), CursorKeepRow, 4, []string{"eight", "nine", "ten"}, "eight", []string{"two", "three", "four"}, "two"},
+		{Top(), CursorKeepRow, 8, []string{"ten"}, "ten", []string{"six", "seven", "eight"}, "six"},
+		{Top(), CursorFirstRow, 4, []string{"eight", "nine", "ten"}, "eight", []string{"two", "three", "four"}, "two"},
+		{Top(), CursorFirstRow, 8, []string{"ten"}, "ten", []string{"six", "seven", "eight"}, "six"},
+		{Top(), CursorLastRow, 4, []string{"eight", "nine", "ten"}, "eight", []string{"two", "three", "four"}, "two"},
+		{Top(), CursorLastRow, 8, []string{"ten"}, "ten", []string{"six", "seven", "eight"}, "six"},
+		{Top(), CursorEdge, 4, []string{"seven", "eight", "nine"}, "seven", []string{"two", "three", "four"}, "two"},
+		{Top(), CursorEdge, 8, []string{"ten"}, "ten", []string{"six", "seven", "eight"}, "six"},
+		{Bottom(), CursorKeepRow, 3, []string{"five", "six", "seven"}, "seven", []string{"one", "two", "three"}, "three"},
+		{Bottom(), CursorKeepRow, 4, []string{"six", "seven", "eight"}, "eight", []string{"one", "two", "three"}, "three"},
+		{Bottom(), CursorKeepRow, 8, []string{"eight", "nine", "ten"}, "ten", []string{"four", "five", "six"}, "six"},
+		{Bottom(), CursorFirstRow, 3, []string{"five", "six", "seven"}, "seven", []string{"one", "two", "three"}, "one"},
+		{Bottom(), CursorFirstRow, 4, []string{"six", "seven", "eight"}, "eight", []string{"one", "two", "three"}, "one"},
+		{Bottom(), CursorFirstRow, 8, []string{"eight", "nine", "ten"}, "ten", []string{"four", "five", "six"}, "six"},
+		{Bottom(), CursorLastRow, 3, []string{"five", "six", "seven"}, "seven", []string{"one", "two", "three"}, "three"},
+		{Bottom(), CursorLastRow, 4, []string{"six", "seven", "eight"}, "eight", []string{"one", "two", "three"}, "three"},
+		{Bottom(), CursorLastRow, 8, []string{"eight", "nine", "ten"}, "ten", []string{"four", "five", "six"}, "six"},
+		{Bottom(), CursorEdge, 4, []string{"six", "seven", "eight"}, "eight", []string{"one", "two", "three"}, "three"},
+		{Bottom(), CursorEdge, 8, []string{"eight", "nine", "ten"}, "ten", []string{"five", "six", "seven"}, "seven"},
+	}
+
+	for i, test := range tests {
+		pg := New(3, len(data), test.style, PageCursor(test.mode))
+		Select(pg, test.selected)
+		pg.PageDown()
+
+		lines, selected := displayData(pg)
+
+		if got, want := lines, test.down; !reflect.DeepEqual(got, want) {
+			t.Errorf("[%v] PageDown(); lines = %#v; want %#v", i, got, want)
+		}
+
+		if got, want := selected, test.downSel; got != want {
+			t.Errorf("[%v] PageDown(); selected = %#v; want %#v", i, got, want)
+		}
+
+		pg = New(3, len(data), test.style, PageCursor(test.mode))
+		Select(pg, test.selected)
+		pg.PageUp()
+
+		lines, selected = displayData(pg)
+
+		if got, want := lines, test.up; !reflect.DeepEqual(got, want) {
+			t.Errorf("[%v] PageUp(); lines = %#v; want %#v", i, got, want)
+		}
+
+		if got, want := selected, test.upSel; got != want {
+			t.Errorf("[%v] PageUp(); selected = %#v; want %#v", i, got, want)
+		}
+	}
+}
+
+func TestPageCursorDirection(t *testing.T) {
+	for _, style := range []Option{FixPage(), Top(), Bottom()} {
+		for mode := CursorKeepRow; mode <= CursorEdge; mode++ {
+			for start := range len(data) {
+				pg := New(3, len(data), style, PageCursor(mode))
+				Select(pg, start)
+				pg.PageDown()
+
+				if got := selectedIndex(pg); got < start || (got == start && start < len(data)-1) {
+					t.Errorf("%v, mode %v; PageDown() from %v selects %v", pg.State().Style, mode, start, got)
+				}
+
+				pg = New(3, len(data), style, PageCursor(mode))
+				Select(pg, start)
+				pg.PageUp()
+
+				if got := selectedIndex(pg); got > start || (got == start && start > 0) {
+					t.Errorf("%v, mode %v; PageUp() from %v selects %v", pg.State().Style, mode, start, got)
+				}
+			}
+		}
+	}
+}
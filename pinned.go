@@ -0,0 +1,70 @@
+package pager
+
+// Pinned describes the rows that don't scroll, see Header, Footer and StickyGroups.
+// On the screen, the header rows are followed by the sticky group row,
+// the scrolling rows (see Indexes) and the footer rows.
+type Pinned struct {
+	// Header and Footer are the number of fixed rows above and below the scrolling rows.
+	Header, Footer int
+
+	// Sticky is true, if there is a row for the group of the first shown item.
+	Sticky bool
+
+	// GroupStart is the index of the first item of the group of the first
+	// shown item, whose ID is to be shown in the sticky group row.
+	// GroupStart is -1, if there is no sticky group row or no data.
+	GroupStart int
+}
+
+// Header reserves the given number of fixed rows at the top, e.g. for the column headers of a table.
+// The height of New and Resize includes these rows.
+func Header(rows uint) Option {
+	return func(pg *pager) {
+		pg.state.Header = int(rows)
+	}
+}
+
+// Footer reserves the given number of fixed rows at the bottom, e.g. for a totals row.
+// The height of New and Resize includes these rows.
+func Footer(rows uint) Option {
+	return func(pg *pager) {
+		pg.state.Footer = int(rows)
+	}
+}
+
+// StickyGroups reserves a fixed row below the header rows that shows the group
+// of the first shown item, see Groups and Pinned.
+func StickyGroups() Option {
+	return func(pg *pager) {
+		pg.state.Sticky = true
+	}
+}
+
+// Pinned returns the rows that don't scroll.
+func (s State) Pinned() Pinned {
+	pn := Pinned{Header: s.Header, Footer: s.Footer, Sticky: s.Sticky, GroupStart: -1}
+
+	from, _, _ := s.Indexes()
+	if !pn.Sticky || from == -1 {
+		return pn
+	}
+
+	if start, ok := s.prevGroup(from + 1); ok {
+		pn.GroupStart = start
+	}
+	return pn
+}
+
+// pinned returns the number of rows that don't scroll.
+func (s State) pinned() int {
+	n := s.Header + s.Footer
+	if s.Sticky {
+		n++
+	}
+	return n
+}
+
+// bodyHeight returns the number of scrolling rows for the given height, at least 1.
+func (s State) bodyHeight(height int) int {
+	return max(height-s.pinned(), 1)
+}
@@ -0,0 +1,70 @@
+package pager
+
+import (
+	"testing"
+)
+
+func TestPinned(t *testing.T) {
+	group := customers(3, 1, 4, 2)
+	pg := New(6, len(data), Header(1), Footer(1), StickyGroups(), Groups(group))
+
+	if got, want := pg.State().Height, 3; got != want {
+		t.Errorf("Height = %v; want %v", got, want)
+	}
+
+	tests := []struct {
+		selected   int
+		group      string
+		groupStart int
+	}{
+		{0, "a", 0},
+		{3, "b", 3},
+		{6, "c", 4},
+		{9, "c", 4},
+	}
+
+	for _, test := range tests {
+		Select(pg, test.selected)
+		pn := pg.State().Pinned()
+
+		if pn.Header != 1 || pn.Footer != 1 || !pn.Sticky {
+			t.Errorf("Select(%v); Pinned() = %+v; want 1 header, 1 footer and a sticky row", test.selected, pn)
+		}
+
+		if got, want := pn.GroupStart, test.groupStart; got != want {
+			t.Errorf("Select(%v); Pinned().GroupStart = %v; want %v", test.selected, got, want)
+		}
+
+		if got, want := group(pn.GroupStart), test.group; got != want {
+			t.Errorf("Select(%v); group(Pinned().GroupStart) = %q; want %q", test.selected, got, want)
+		}
+	}
+
+	Resize(pg, 10)
+
+	if got, want := pg.State().Height, 7; got != want {
+		t.Errorf("Resize(10); Height = %v; want %v", got, want)
+	}
+
+	Resize(pg, 2)
+
+	if got, want := pg.State().Height, 1; got != want {
+		t.Errorf("Resize(2); Height = %v; want %v", got, want)
+	}
+}
+
+func TestPinnedWithoutGroups(t *testing.T) {
+	pg := New(3, len(data), Header(1))
+
+	if got, want := pg.State().Height, 2; got != want {
+		t.Errorf("Height = %v; want %v", got, want)
+	}
+
+	if got, want := pg.State().Pinned(), (Pinned{Header: 1, GroupStart: -1}); got != want {
+		t.Errorf("Pinned() = %+v; want %+v", got, want)
+	}
+
+	if got, want := New(3, 0, StickyGroups(), Groups(customers())).State().Pinned(), (Pinned{Sticky: true, GroupStart: -1}); got != want {
+		t.Errorf("empty; Pinned() = %+v; want %+v", got, want)
+	}
+}
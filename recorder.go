@@ -0,0 +1,193 @@
+package pager
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Entry is a recorded call of a Recorder.
+type Entry struct {
+	Action Action `json:"action"`
+
+	// Changed is the result of the call: wether the selected item has changed,
+	// or for ScrollDown, ScrollUp and ScrollTo, wether the viewport has moved.
+	Changed bool `json:"changed"`
+
+	// From, To and Selected are the indexes after the call.
+	From     int `json:"from"`
+	To       int `json:"to"`
+	Selected int `json:"selected"`
+}
+
+// String returns the entry in the text format, e.g.
+//
+//	select 4 => 1 3 6 1
+//
+// followed by the changed flag (0 or 1) and the indexes after the call.
+// The names of marks are quoted, e.g. setmark "a".
+func (e Entry) String() string {
+	changed := 0
+	if e.Changed {
+		changed = 1
+	}
+	return fmt.Sprintf("%s => %d %d %d %d", e.Action, changed, e.From, e.To, e.Selected)
+}
+
+// ParseEntry parses an entry in the text format, see Entry.String.
+func ParseEntry(s string) (e Entry, err error) {
+	// the last arrow, since a quoted name may contain one
+	i := strings.LastIndex(s, "=>")
+	if i == -1 {
+		return e, fmt.Errorf("pager: invalid entry %q", s)
+	}
+	call, result := s[:i], s[i+len("=>"):]
+
+	fields := strings.Fields(call)
+	if len(fields) == 0 {
+		return e, fmt.Errorf("pager: invalid entry %q", s)
+	}
+
+	if err = e.Action.Op.UnmarshalText([]byte(fields[0])); err != nil {
+		return e, err
+	}
+
+	args := fields[1:]
+	switch e.Action.Op.args() {
+	case argNone:
+		err = wantArgs(args, 0)
+	case argN:
+		if err = wantArgs(args, 1); err == nil {
+			e.Action.N, err = strconv.Atoi(args[0])
+		}
+	case argAtN:
+		if err = wantArgs(args, 2); err == nil {
+			e.Action.At, err = strconv.Atoi(args[0])
+		}
+		if err == nil {
+			e.Action.N, err = strconv.Atoi(args[1])
+		}
+	case argName:
+		name := strings.TrimSpace(strings.TrimSpace(call)[len(fields[0]):])
+		e.Action.Name, err = strconv.Unquote(name)
+	case argNAlign:
+		if err = wantArgs(args, 2); err == nil {
+			e.Action.N, err = strconv.Atoi(args[0])
+		}
+		if err == nil {
+			err = e.Action.Align.UnmarshalText([]byte(args[1]))
+		}
+	}
+
+	if err != nil {
+		return e, fmt.Errorf("pager: invalid entry %q", s)
+	}
+
+	var changed int
+	n, err := fmt.Sscanf(result, "%d %d %d %d", &changed, &e.From, &e.To, &e.Selected)
+	if err != nil || n != 4 || (changed != 0 && changed != 1) {
+		return e, fmt.Errorf("pager: invalid entry %q", s)
+	}
+	e.Changed = changed == 1
+
+	return e, nil
+}
+
+func wantArgs(args []string, n int) error {
+	if len(args) != n {
+		return fmt.Errorf("pager: %d arguments expected", n)
+	}
+	return nil
+}
+
+// ReadEntries reads entries in the text format, one per line.
+// Empty lines and lines starting with # are skipped.
+func ReadEntries(r io.Reader) (entries []Entry, err error) {
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		e, err := ParseEntry(line)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+	return entries, sc.Err()
+}
+
+// WriteEntries writes the entries in the text format, one per line.
+func WriteEntries(w io.Writer, entries []Entry) error {
+	for _, e := range entries {
+		if _, err := fmt.Fprintln(w, e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Recorder is a Dispatcher that records every call that changes the pager.
+// The entries can be written as text (see WriteEntries) or as JSON.
+type Recorder struct {
+	wrapper
+	Entries []Entry
+}
+
+// Record returns a recorder that wraps the given pager.
+func Record(p Dispatcher) *Recorder {
+	r := &Recorder{}
+	r.wrapper = wrapper{p, r.record}
+	return r
+}
+
+func (r *Recorder) record(a Action) Effect {
+	effect := r.Dispatcher.Dispatch(a)
+	r.Entries = append(r.Entries, entry(r.Dispatcher, a, effect))
+	return effect
+}
+
+func entry(p Pager, a Action, effect Effect) Entry {
+	from, to, selected := p.Indexes()
+	return Entry{Action: a, Changed: result(a.Op, effect), From: from, To: to, Selected: selected}
+}
+
+// result returns the result of the method or function of the given operation.
+func result(op Op, effect Effect) bool {
+	switch op {
+	case OpScrollDown, OpScrollUp, OpScrollTo:
+		return effect.Scrolled()
+	}
+	return effect.Changed()
+}
+
+// Divergence is returned by Replay, if the replayed pager behaves differently.
+type Divergence struct {
+	// Index is the index of the first diverging entry.
+	Index int
+
+	// Want is the recorded entry, Got the replayed one.
+	Want, Got Entry
+}
+
+// Error returns the error message.
+func (d *Divergence) Error() string {
+	return fmt.Sprintf("pager: replay diverges at entry %d: got %q; want %q", d.Index, d.Got, d.Want)
+}
+
+// Replay applies the actions of the given entries to the given pager, which
+// should be created like the recorded one. It returns a *Divergence for the first
+// entry whose result differs.
+func Replay(p Dispatcher, entries []Entry) error {
+	for i, want := range entries {
+		got := entry(p, want.Action, p.Dispatch(want.Action))
+		if got != want {
+			return &Divergence{Index: i, Want: want, Got: got}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+package pager
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func recordSession() *Recorder {
+	r := Record(New(3, len(data)))
+	r.Next()
+	r.PageDown()
+	Select(r, 7)
+	Resize(r, 4)
+	r.PageUp()
+	SetLen(r, 5)
+	Last(r)
+	r.Next()
+	return r
+}
+
+func TestRecorderText(t *testing.T) {
+	r := recordSession()
+
+	var bf bytes.Buffer
+	if err := WriteEntries(&bf, r.Entries); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `next => 1 0 3 1
+pagedown => 1 3 6 2
+select 7 => 1 6 9 1
+resize 4 => 0 4 8 3
+pageup => 1 0 4 3
+setlen 5 => 0 0 4 3
+last => 1 4 5 0
+next => 0 4 5 0
+`
+
+	if got := bf.String(); got != want {
+		t.Errorf("WriteEntries() = \n%s\nwant\n%s", got, want)
+	}
+
+	entries, err := ReadEntries(strings.NewReader("# session\n\n" + want))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := entries, r.Entries; !reflect.DeepEqual(got, want) {
+		t.Errorf("ReadEntries() = %v; want %v", got, want)
+	}
+}
+
+func TestRecorderResults(t *testing.T) {
+	r := Record(New(3, len(data), PreSelect(5)))
+
+	results := []bool{
+		ScrollDown(r, 1),
+		ScrollUp(r, 1),
+		ScrollTo(r, 5, AlignNearest),
+		r.Next(),
+	}
+
+	if got, want := results, []bool{true, true, false, true}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("results = %v; want %v", got, want)
+	}
+
+	for i, e := range r.Entries {
+		if got, want := e.Changed, results[i]; got != want {
+			t.Errorf("Entries[%v].Changed = %v; want %v (%v)", i, got, want, e.Action)
+		}
+	}
+}
+
+func TestRecorderJSON(t *testing.T) {
+	r := recordSession()
+
+	b, err := json.Marshal(r.Entries)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := string(b[:60]), `[{"action":{"op":"next"},"changed":true,"from":0,"to":3,"sel`; got != want {
+		t.Errorf("json = %s; want %s", got, want)
+	}
+
+	var entries []Entry
+	if err := json.Unmarshal(b, &entries); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := entries, r.Entries; !reflect.DeepEqual(got, want) {
+		t.Errorf("json.Unmarshal() = %v; want %v", got, want)
+	}
+}
+
+func TestReplay(t *testing.T) {
+	r := recordSession()
+
+	if err := Replay(New(3, len(data)), r.Entries); err != nil {
+		t.Errorf("Replay() = %v", err)
+	}
+
+	err := Replay(New(3, len(data), Top()), r.Entries)
+
+	d, ok := err.(*Divergence)
+	if !ok {
+		t.Fatalf("Replay() = %#v; want *Divergence", err)
+	}
+
+	if got, want := d.Index, 0; got != want {
+		t.Errorf("Divergence.Index = %v; want %v", got, want)
+	}
+
+	if got, want := d.Got.String(), "next => 1 1 4 0"; got != want {
+		t.Errorf("Divergence.Got = %q; want %q", got, want)
+	}
+}
+
+func TestParseEntry(t *testing.T) {
+	tests := []Entry{
+		{Action: ActInsert(2, 3), Changed: true, From: 3, To: 6, Selected: 1},
+		{Action: ActDelete(0, 1), From: 0, To: 3, Selected: 0},
+		{Action: ActSetMark("a"), From: 0, To: 3, Selected: 2},
+		{Action: ActSetMark("my mark"), From: 0, To: 3, Selected: 2},
+		{Action: ActSetMark(""), From: 0, To: 3, Selected: 2},
+		{Action: ActSetMark(`a => "b"`), From: 0, To: 3, Selected: 2},
+		{Action: ActJumpToMark("a"), Changed: true, From: 0, To: 3, Selected: 2},
+		{Action: ActDeleteMark("a"), From: -1, To: -1, Selected: -1},
+		{Action: ActScrollTo(4, AlignCenter), Changed: true, From: 3, To: 6, Selected: 1},
+		{Action: ActScrollTo(0, AlignTop), From: 0, To: 3, Selected: 0},
+	}
+
+	for _, test := range tests {
+		got, err := ParseEntry(test.String())
+
+		if err != nil {
+			t.Errorf("ParseEntry(%q); err = %v", test.String(), err)
+			continue
+		}
+
+		if got != test {
+			t.Errorf("ParseEntry(%q) = %#v; want %#v", test.String(), got, test)
+		}
+	}
+}
+
+func TestParseEntryErrors(t *testing.T) {
+	tests := []string{
+		"",
+		"next",
+		"jump => 1 0 3 1",
+		"select => 1 0 3 1",
+		"next 3 => 1 0 3 1",
+		"next => 2 0 3 1",
+		"next => 1 0 3",
+		"insert 2 => 1 0 3 1",
+		"setmark => 1 0 3 1",
+		"setmark a => 1 0 3 1",
+		`setmark "a" "b" => 1 0 3 1`,
+		"scrollto 4 => 1 3 6 1",
+		"scrollto 4 middle => 1 3 6 1",
+	}
+
+	for _, test := range tests {
+		if _, err := ParseEntry(test); err == nil {
+			t.Errorf("ParseEntry(%q); err = nil", test)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package pager
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const relayCursorPrefix = "pager:"
+
+// ConnectionArgs are the arguments of a connection field of the
+// GraphQL Cursor Connections Specification (Relay).
+// Nil means that the argument is not given.
+type ConnectionArgs struct {
+	First  *int
+	After  *string
+	Last   *int
+	Before *string
+}
+
+// PageInfo is the pageInfo object of a connection.
+// Empty cursors correspond to null.
+type PageInfo struct {
+	HasPreviousPage bool   `json:"hasPreviousPage"`
+	HasNextPage     bool   `json:"hasNextPage"`
+	StartCursor     string `json:"startCursor,omitempty"`
+	EndCursor       string `json:"endCursor,omitempty"`
+}
+
+// ConnectionError is returned for invalid connection arguments.
+type ConnectionError struct {
+	Arg    string
+	Reason string
+}
+
+// Error returns the error message.
+func (e *ConnectionError) Error() string {
+	return fmt.Sprintf("pager: invalid argument %s: %s", e.Arg, e.Reason)
+}
+
+// EdgeCursor returns the cursor of the edge at the given index.
+func EdgeCursor(index int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(relayCursorPrefix + strconv.Itoa(index)))
+}
+
+// Connection returns the range of the edges and the pageInfo for the given
+// arguments and the total number of edges, following the pagination algorithm
+// of the specification. The edges are data[from:to], and the cursor of the
+// edge data[i] is EdgeCursor(i).
+//
+// After and Before are applied first, then First and Last. Cursors
+// that point beyond the data are clamped to it. Since the cursors are
+// positions, hasPreviousPage and hasNextPage are also reported for
+// After and Before.
+func Connection(args ConnectionArgs, total int) (from, to int, info PageInfo, err error) {
+	from, to = 0, total
+
+	if args.After != nil {
+		after, err := decodeEdgeCursor("after", *args.After)
+		if err != nil {
+			return 0, 0, info, err
+		}
+		if after+1 > from {
+			from = after + 1
+		}
+	}
+
+	if args.Before != nil {
+		before, err := decodeEdgeCursor("before", *args.Before)
+		if err != nil {
+			return 0, 0, info, err
+		}
+		if before < to {
+			to = before
+		}
+	}
+
+	if from > to {
+		from = to
+	}
+
+	info.HasPreviousPage = from > 0 && args.After != nil
+	info.HasNextPage = to < total && args.Before != nil
+
+	if args.First != nil {
+		first := *args.First
+		if first < 0 {
+			return 0, 0, info, &ConnectionError{"first", "must not be negative"}
+		}
+
+		info.HasNextPage = to-from > first
+		from, to = window(first, from, to, Top(), PreSelect(0))
+	}
+
+	if args.Last != nil {
+		last := *args.Last
+		if last < 0 {
+			return 0, 0, info, &ConnectionError{"last", "must not be negative"}
+		}
+
+		info.HasPreviousPage = to-from > last
+		from, to = window(last, from, to, Bottom(), PreSelect(uint(to-from-1)))
+	}
+
+	if from < to {
+		info.StartCursor = EdgeCursor(from)
+		info.EndCursor = EdgeCursor(to - 1)
+	}
+
+	return from, to, info, nil
+}
+
+// window returns the part of [from,to) that a pager of the given height
+// and options shows.
+func window(height, from, to int, opts ...Option) (int, int) {
+	if height == 0 || from == to {
+		return from, from
+	}
+
+	f, t, _ := New(height, to-from, opts...).Indexes()
+	return from + f, from + t
+}
+
+func decodeEdgeCursor(arg, cursor string) (int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil || !strings.HasPrefix(string(b), relayCursorPrefix) {
+		return 0, &ConnectionError{arg, "invalid cursor"}
+	}
+
+	i, err := strconv.Atoi(strings.TrimPrefix(string(b), relayCursorPrefix))
+	if err != nil || i < 0 {
+		return 0, &ConnectionError{arg, "invalid cursor"}
+	}
+	return i, nil
+}
@@ -0,0 +1,93 @@
+package pager
+
+import (
+	"testing"
+)
+
+func TestConnection(t *testing.T) {
+	i := func(i int) *int { return &i }
+	c := func(i int) *string { s := EdgeCursor(i); return &s }
+
+	tests := []struct {
+		args      ConnectionArgs
+		total     int
+		from, to  int
+		prev, nxt bool
+	}{
+		{ConnectionArgs{}, 10, 0, 10, false, false},
+		{ConnectionArgs{First: i(3)}, 10, 0, 3, false, true},
+		{ConnectionArgs{First: i(3), After: c(2)}, 10, 3, 6, true, true},
+		{ConnectionArgs{First: i(3), After: c(8)}, 10, 9, 10, true, false},
+		{ConnectionArgs{First: i(3), After: c(9)}, 10, 10, 10, true, false},
+		{ConnectionArgs{First: i(3), After: c(42)}, 10, 10, 10, true, false},
+		{ConnectionArgs{First: i(20)}, 10, 0, 10, false, false},
+		{ConnectionArgs{First: i(0)}, 10, 0, 0, false, true},
+		{ConnectionArgs{Last: i(3)}, 10, 7, 10, true, false},
+		{ConnectionArgs{Last: i(3), Before: c(7)}, 10, 4, 7, true, true},
+		{ConnectionArgs{Last: i(3), Before: c(2)}, 10, 0, 2, false, true},
+		{ConnectionArgs{Last: i(20)}, 10, 0, 10, false, false},
+		{ConnectionArgs{After: c(2), Before: c(6)}, 10, 3, 6, true, true},
+		{ConnectionArgs{First: i(4), Last: i(2)}, 10, 2, 4, true, true},
+		{ConnectionArgs{First: i(3)}, 0, 0, 0, false, false},
+		{ConnectionArgs{Last: i(3)}, 0, 0, 0, false, false},
+	}
+
+	for _, test := range tests {
+		from, to, info, err := Connection(test.args, test.total)
+
+		if err != nil {
+			t.Errorf("Connection(%v); err = %v", test, err)
+			continue
+		}
+
+		if from != test.from || to != test.to {
+			t.Errorf("Connection(%v); from, to = %v, %v; want %v, %v", test, from, to, test.from, test.to)
+		}
+
+		if got, want := info.HasPreviousPage, test.prev; got != want {
+			t.Errorf("Connection(%v); hasPreviousPage = %v; want %v", test, got, want)
+		}
+
+		if got, want := info.HasNextPage, test.nxt; got != want {
+			t.Errorf("Connection(%v); hasNextPage = %v; want %v", test, got, want)
+		}
+
+		wantStart, wantEnd := "", ""
+		if from < to {
+			wantStart, wantEnd = EdgeCursor(from), EdgeCursor(to-1)
+		}
+
+		if info.StartCursor != wantStart || info.EndCursor != wantEnd {
+			t.Errorf("Connection(%v); cursors = %q, %q; want %q, %q", test, info.StartCursor, info.EndCursor, wantStart, wantEnd)
+		}
+	}
+}
+
+func TestConnectionErrors(t *testing.T) {
+	i := func(i int) *int { return &i }
+	s := func(s string) *string { return &s }
+
+	tests := []struct {
+		args ConnectionArgs
+		arg  string
+	}{
+		{ConnectionArgs{First: i(-1)}, "first"},
+		{ConnectionArgs{Last: i(-1)}, "last"},
+		{ConnectionArgs{After: s("nonsense")}, "after"},
+		{ConnectionArgs{Before: s(EdgeCursor(-3))}, "before"},
+	}
+
+	for _, test := range tests {
+		_, _, _, err := Connection(test.args, 10)
+
+		cerr, ok := err.(*ConnectionError)
+		if !ok {
+			t.Errorf("Connection(%v); err = %#v; want *ConnectionError", test.args, err)
+			continue
+		}
+
+		if got, want := cerr.Arg, test.arg; got != want {
+			t.Errorf("Connection(%v); err.Arg = %q; want %q", test.args, got, want)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package pager
+
+// Scrollbar computes the thumb of a scrollbar for a pager.
+//
+// The thumb touches the start of the track exactly if the first item is shown
+// and the end of the track exactly if the last item is shown. Otherwise
+// there is a gap of at least one unit at both ends, if the track allows it.
+//
+// The pages of grouped items (see Groups) have different lengths, so the thumb
+// of a grouped pager with StyleFixPage stands for its current page.
+type Scrollbar struct {
+	// Track is the length of the track in cells.
+	Track int
+
+	// MinThumb is the minimum length of the thumb in cells. Values below 1 are treated as 1.
+	MinThumb int
+}
+
+// Thumb returns the offset and the length of the thumb in cells.
+func (sb Scrollbar) Thumb(pg Dispatcher) (offset, length int) {
+	return sb.thumb(pg, 1)
+}
+
+// Eighths returns the offset and the length of the thumb in eighths of a cell,
+// for the rendering with the Unicode block elements (e.g. ▁ to █).
+func (sb Scrollbar) Eighths(pg Dispatcher) (offset, length int) {
+	return sb.thumb(pg, 8)
+}
+
+// thumb returns the offset and the length of the thumb in the given fractions of a cell.
+func (sb Scrollbar) thumb(pg Dispatcher, scale int) (offset, length int) {
+	units := sb.Track * scale
+	if units <= 0 {
+		return 0, 0
+	}
+
+	from, to, _ := pg.Indexes()
+	if from == -1 {
+		return 0, units
+	}
+
+	// the thumb is sized by the height, so that a short last page doesn't shrink it
+	s := pg.State()
+	total, shown, pos, end := s.Len, min(s.Height, s.Len), from, to >= s.Len
+	if s.groupedPages() {
+		page, _, _ := s.pageOf(from)
+		total, shown, pos, end = len(s.PageStarts), 1, page, page == len(s.PageStarts)-1
+	}
+
+	if shown >= total {
+		return 0, units
+	}
+
+	length = divRound(units*shown, total)
+	length = min(max(length, max(sb.MinThumb, 1)*scale), units)
+
+	space := units - length
+	switch {
+	case pos == 0:
+		return 0, length
+	case end:
+		return space, length
+	}
+
+	offset = divRound(space*pos, total-shown)
+	if space >= 2 {
+		offset = min(max(offset, 1), space-1)
+	}
+	return offset, length
+}
+
+// divRound returns a/b, rounded to the nearest integer. a and b must not be negative.
+func divRound(a, b int) int {
+	return (a + b/2) / b
+}
+
+// OffsetAt returns the index of the first item to show, if the thumb
+// starts at the given position of the track in cells, e.g. while it is dragged.
+// pos is clamped to the track. For the pages of grouped items, it is the first
+// index of a page.
+func (sb Scrollbar) OffsetAt(pg Dispatcher, pos int) int {
+	from, _, _ := pg.Indexes()
+	if from == -1 {
+		return 0
+	}
+
+	_, length := sb.Thumb(pg)
+	space := sb.Track - length
+
+	s := pg.State()
+	if s.groupedPages() {
+		pages := len(s.PageStarts)
+		if space <= 0 || pages <= 1 {
+			return 0
+		}
+
+		pos = min(max(pos, 0), space)
+		return s.PageStarts[divRound(pos*(pages-1), space)]
+	}
+
+	scrollable := s.Len - min(s.Height, s.Len)
+	if space <= 0 || scrollable <= 0 {
+		return 0
+	}
+
+	pos = min(max(pos, 0), space)
+	return divRound(pos*scrollable, space)
+}
+
+// Drag scrolls the pager, so that the thumb starts at the given position
+// of the track in cells (see OffsetAt). The selection is dragged along,
+// like with ScrollDown and ScrollUp. For the pages of grouped items,
+// the first item of the page is selected. Returns wether the viewport has moved.
+func (sb Scrollbar) Drag(pg Dispatcher, pos int) (scrolled bool) {
+	from, _, _ := pg.Indexes()
+	if from == -1 {
+		return false
+	}
+
+	offset := sb.OffsetAt(pg, pos)
+	if pg.State().groupedPages() {
+		return offset != from && pg.Dispatch(ActSelect(offset)).Scrolled()
+	}
+
+	switch n := offset - from; {
+	case n > 0:
+		return ScrollDown(pg, n)
+	case n < 0:
+		return ScrollUp(pg, -n)
+	}
+	return false
+}
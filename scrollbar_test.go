@@ -0,0 +1,137 @@
+package pager
+
+import (
+	"testing"
+)
+
+func TestScrollbar(t *testing.T) {
+	tests := []struct {
+		sb       Scrollbar
+		dataLen  int
+		opts     []Option
+		selected int
+		offset   int
+		length   int
+	}{
+		{Scrollbar{Track: 20}, 100, nil, 0, 0, 2},
+		{Scrollbar{Track: 20}, 100, nil, 50, 10, 2},
+		{Scrollbar{Track: 20}, 100, nil, 99, 18, 2},
+		{Scrollbar{Track: 20}, 95, nil, 94, 18, 2},
+		{Scrollbar{Track: 20}, 100, []Option{Top()}, 1, 1, 2},
+		{Scrollbar{Track: 20}, 100, []Option{Top()}, 89, 17, 2},
+		{Scrollbar{Track: 20}, 100, []Option{Top()}, 90, 18, 2},
+		{Scrollbar{Track: 20}, 1000, nil, 0, 0, 1},
+		{Scrollbar{Track: 20, MinThumb: 3}, 1000, nil, 500, 9, 3},
+		{Scrollbar{Track: 20, MinThumb: 30}, 1000, nil, 500, 0, 20},
+		{Scrollbar{Track: 20}, 5, nil, 4, 0, 20},
+		{Scrollbar{Track: 20}, 0, nil, 0, 0, 20},
+		{Scrollbar{Track: 0}, 100, nil, 50, 0, 0},
+	}
+
+	for i, test := range tests {
+		pg := New(10, test.dataLen, test.opts...)
+		Select(pg, test.selected)
+
+		offset, length := test.sb.Thumb(pg)
+
+		if got, want := offset, test.offset; got != want {
+			t.Errorf("[%v] Thumb(); offset = %v; want %v", i, got, want)
+		}
+
+		if got, want := length, test.length; got != want {
+			t.Errorf("[%v] Thumb(); length = %v; want %v", i, got, want)
+		}
+	}
+}
+
+func TestScrollbarEighths(t *testing.T) {
+	sb := Scrollbar{Track: 20}
+	pg := New(10, 100, Top())
+
+	tests := []struct {
+		selected int
+		offset   int
+	}{
+		{0, 0},
+		{1, 2},
+		{45, 72},
+		{89, 142},
+		{90, 144},
+	}
+
+	for _, test := range tests {
+		Select(pg, test.selected)
+		offset, length := sb.Eighths(pg)
+
+		if got, want := offset, test.offset; got != want {
+			t.Errorf("Select(%v); Eighths(); offset = %v; want %v", test.selected, got, want)
+		}
+
+		if got, want := length, 16; got != want {
+			t.Errorf("Select(%v); Eighths(); length = %v; want %v", test.selected, got, want)
+		}
+	}
+}
+
+func TestScrollbarGroups(t *testing.T) {
+	sb := Scrollbar{Track: 10}
+	pg := New(3, 10, Groups(customers(2, 2, 2, 2, 2)))
+
+	tests := []struct {
+		pos            int
+		from           int
+		offset, length int
+	}{
+		{4, 4, 4, 2},
+		{8, 8, 8, 2},
+		{0, 0, 0, 2},
+		{2, 2, 2, 2},
+	}
+
+	for _, test := range tests {
+		sb.Drag(pg, test.pos)
+
+		if from, _, _ := pg.Indexes(); from != test.from {
+			t.Errorf("Drag(%v); from = %v; want %v", test.pos, from, test.from)
+		}
+
+		offset, length := sb.Thumb(pg)
+
+		if offset != test.offset || length != test.length {
+			t.Errorf("Drag(%v); Thumb() = %v, %v; want %v, %v", test.pos, offset, length, test.offset, test.length)
+		}
+	}
+}
+
+func TestScrollbarDrag(t *testing.T) {
+	sb := Scrollbar{Track: 20}
+	pg := New(10, 100)
+
+	tests := []struct {
+		pos  int
+		from int
+	}{
+		{9, 45},
+		{0, 0},
+		{18, 90},
+		{25, 90},
+		{1, 5},
+		{-3, 0},
+	}
+
+	for _, test := range tests {
+		sb.Drag(pg, test.pos)
+
+		if from, _, _ := pg.Indexes(); from != test.from {
+			t.Errorf("Drag(%v); from = %v; want %v", test.pos, from, test.from)
+		}
+
+		if offset, _ := sb.Thumb(pg); test.pos >= 0 && test.pos <= 18 && offset != test.pos {
+			t.Errorf("Drag(%v); thumb offset = %v; want %v", test.pos, offset, test.pos)
+		}
+	}
+
+	if got, want := sb.OffsetAt(New(10, 5), 3), 0; got != want {
+		t.Errorf("OffsetAt(3) with all items shown = %v; want %v", got, want)
+	}
+}
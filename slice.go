@@ -0,0 +1,61 @@
+package pager
+
+import (
+	"iter"
+)
+
+// Slice is a pager that owns its data and returns the shown items.
+// The navigation is done via the embedded Dispatcher.
+// The data must only be changed via Set. If the length of the pager is
+// changed otherwise, the rows beyond the data are left out.
+type Slice[T any] struct {
+	Dispatcher
+	data []T
+}
+
+// NewSlice creates a pager for the given data.
+func NewSlice[T any](height int, data []T, opts ...Option) *Slice[T] {
+	return &Slice[T]{
+		Dispatcher: New(height, len(data), opts...),
+		data:       data,
+	}
+}
+
+// Visible returns the shown items.
+func (s *Slice[T]) Visible() []T {
+	from, to, _ := s.Indexes()
+	if from == -1 || from >= len(s.data) {
+		return nil
+	}
+	return s.data[from:min(to, len(s.data))]
+}
+
+// Current returns the selected item. ok is false, if there is no selection.
+func (s *Slice[T]) Current() (item T, ok bool) {
+	from, _, selected := s.Indexes()
+	if from == -1 || selected == -1 || from+selected >= len(s.data) {
+		return item, false
+	}
+	return s.data[from+selected], true
+}
+
+// Set replaces the data. The index of the selected item is kept,
+// as long as it is within the new data, otherwise the last item is selected.
+func (s *Slice[T]) Set(data []T) {
+	SetLen(s, len(data))
+	s.data = data
+}
+
+// Items iterates over the shown rows and their items.
+func (s *Slice[T]) Items() iter.Seq2[Row, T] {
+	return func(yield func(Row, T) bool) {
+		for i, row := range s.State().Rows() {
+			if i >= len(s.data) {
+				return
+			}
+			if !yield(row, s.data[i]) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package pager
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSlice(t *testing.T) {
+	s := NewSlice(3, data, PreSelect(4))
+
+	if got, want := s.Visible(), []string{"four", "five", "six"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Visible() = %v; want %v", got, want)
+	}
+
+	s.Next()
+
+	if got, ok := s.Current(); got != "six" || !ok {
+		t.Errorf("Current() = %#v, %v; want %#v, true", got, ok, "six")
+	}
+}
+
+func TestSliceSet(t *testing.T) {
+	tests := []struct {
+		selected uint
+		opts     []Option
+		data     []string
+		visible  []string
+		current  string
+	}{
+		{4, nil, data[:8], []string{"four", "five", "six"}, "five"},
+		{4, nil, data[:2], []string{"one", "two"}, "two"},
+		{4, []Option{Top()}, data[:6], []string{"five", "six"}, "five"},
+		{4, nil, nil, nil, ""},
+	}
+
+	for _, test := range tests {
+		s := NewSlice(3, data, append(test.opts, PreSelect(test.selected))...)
+		s.Set(test.data)
+
+		if got, want := s.Visible(), test.visible; !reflect.DeepEqual(got, want) {
+			t.Errorf("Set(%v); Visible() = %v; want %v", test.data, got, want)
+		}
+
+		current, ok := s.Current()
+
+		if got, want := current, test.current; got != want {
+			t.Errorf("Set(%v); Current() = %#v; want %#v", test.data, got, want)
+		}
+
+		if got, want := ok, test.current != ""; got != want {
+			t.Errorf("Set(%v); Current() ok = %v; want %v", test.data, got, want)
+		}
+	}
+}
+
+func TestSliceSetFromEmpty(t *testing.T) {
+	s := NewSlice[string](3, nil)
+
+	if _, ok := s.Current(); ok {
+		t.Errorf("Current() ok = true; want false")
+	}
+
+	s.Set(data)
+
+	if got, ok := s.Current(); got != "one" || !ok {
+		t.Errorf("Current() = %#v, %v; want %#v, true", got, ok, "one")
+	}
+}
+
+func TestSliceItems(t *testing.T) {
+	s := NewSlice(3, data, PreSelect(4))
+
+	var lines []string
+	var current string
+
+	for row, item := range s.Items() {
+		lines = append(lines, item)
+		if row.Selected {
+			current = item
+		}
+	}
+
+	if got, want := lines, []string{"four", "five", "six"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Items() = %v; want %v", got, want)
+	}
+
+	if got, want := current, "five"; got != want {
+		t.Errorf("selected item = %#v; want %#v", got, want)
+	}
+}
+
+func TestSliceLenBeyondData(t *testing.T) {
+	s := NewSlice(4, []int{1, 2, 3})
+	Append(s, 2)
+
+	if got, want := s.Visible(), []int{1, 2, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Visible() = %v; want %v", got, want)
+	}
+
+	var items []int
+	for _, item := range s.Items() {
+		items = append(items, item)
+	}
+
+	if got, want := items, []int{1, 2, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Items() = %v; want %v", got, want)
+	}
+
+	Last(s)
+
+	if got := s.Visible(); got != nil {
+		t.Errorf("Last(); Visible() = %v; want nil", got)
+	}
+
+	if _, ok := s.Current(); ok {
+		t.Errorf("Last(); Current() ok = true; want false")
+	}
+}
@@ -0,0 +1,235 @@
+package pager
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Source is a data source whose items are loaded lazily.
+type Source[T any] interface {
+	// Len returns the number of items.
+	Len() int
+
+	// Load returns the items data[from:to].
+	Load(ctx context.Context, from, to int) ([]T, error)
+}
+
+// Lazy is a pager that loads the shown items from a Source.
+//
+// The items are loaded in blocks of the page height, that are kept in a
+// LRU cache. The blocks are cached by their range, so that the blocks of
+// a former height or length are not used after Resize or SetLen.
+// When the selected item is within the first or last quarter of its block,
+// the previous or next block is prefetched in the background.
+// Loads of blocks that are neither shown nor prefetched any longer are canceled.
+//
+// The navigation is done via the embedded Dispatcher.
+type Lazy[T any] struct {
+	Dispatcher
+	src Source[T]
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu        sync.Mutex
+	cacheSize int
+	lru       *list.List
+	cache     map[lazyKey]*list.Element
+	loading   map[lazyKey]*lazyLoad[T]
+}
+
+// lazyKey is the range data[from:to] of a block.
+type lazyKey struct {
+	from, to int
+}
+
+type lazyBlock[T any] struct {
+	key   lazyKey
+	items []T
+}
+
+type lazyLoad[T any] struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+	items  []T
+	err    error
+}
+
+// NewLazy creates a pager for the items of the given source that caches
+// up to cacheSize blocks. The length of the source is read once.
+func NewLazy[T any](src Source[T], height, cacheSize int, opts ...Option) *Lazy[T] {
+	l := &Lazy[T]{
+		Dispatcher: New(height, src.Len(), opts...),
+		src:        src,
+		cacheSize:  cacheSize,
+		lru:        list.New(),
+		cache:      map[lazyKey]*list.Element{},
+		loading:    map[lazyKey]*lazyLoad[T]{},
+	}
+	l.ctx, l.cancel = context.WithCancel(context.Background())
+	return l
+}
+
+// Visible returns the shown items and the index of the selected item within them.
+// It waits until the needed blocks are loaded or ctx is done.
+// If selected is -1, there is no selection.
+func (l *Lazy[T]) Visible(ctx context.Context) (items []T, selected int, err error) {
+	from, to, selected := l.Indexes()
+	if from == -1 {
+		return nil, -1, nil
+	}
+
+	s := l.State()
+	height, dataLen := s.Height, s.Len
+	first, last := from/height, (to-1)/height
+
+	key := func(block int) lazyKey {
+		return lazyKey{block * height, min(block*height+height, dataLen)}
+	}
+
+	wanted := map[lazyKey]bool{}
+	for b := first; b <= last; b++ {
+		wanted[key(b)] = true
+	}
+
+	var prefetch []int
+	if selected > -1 {
+		pos := (from + selected) % height
+		block := (from + selected) / height
+		near := height / 4
+		if near < 1 {
+			near = 1
+		}
+
+		if pos < near && block > 0 {
+			prefetch = append(prefetch, block-1)
+		}
+
+		if pos >= height-near && (block+1)*height < dataLen {
+			prefetch = append(prefetch, block+1)
+		}
+	}
+
+	for _, b := range prefetch {
+		wanted[key(b)] = true
+	}
+
+	blocks := make([][]T, last-first+1)
+	loads := make([]*lazyLoad[T], last-first+1)
+
+	l.mu.Lock()
+	for k, ld := range l.loading {
+		if !wanted[k] {
+			ld.cancel()
+			delete(l.loading, k)
+		}
+	}
+
+	for b := first; b <= last; b++ {
+		if el, ok := l.cache[key(b)]; ok {
+			l.lru.MoveToFront(el)
+			blocks[b-first] = el.Value.(*lazyBlock[T]).items
+			continue
+		}
+		loads[b-first] = l.load(key(b))
+	}
+
+	for _, b := range prefetch {
+		if _, ok := l.cache[key(b)]; !ok {
+			l.load(key(b))
+		}
+	}
+	l.mu.Unlock()
+
+	for i, ld := range loads {
+		if ld == nil {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, -1, ctx.Err()
+		case <-ld.done:
+		}
+
+		if ld.err != nil {
+			return nil, -1, ld.err
+		}
+		blocks[i] = ld.items
+	}
+
+	items = make([]T, 0, to-from)
+	for _, block := range blocks {
+		items = append(items, block...)
+	}
+
+	offset := from - first*height
+	if len(items) < offset+to-from {
+		return nil, -1, fmt.Errorf("pager: %d items loaded for [%d:%d]", len(items), from, to)
+	}
+	return items[offset : offset+to-from], selected, nil
+}
+
+// Close cancels all running loads.
+func (l *Lazy[T]) Close() {
+	l.cancel()
+}
+
+// load returns the running load of the given block or starts it.
+// l.mu must be held.
+func (l *Lazy[T]) load(key lazyKey) *lazyLoad[T] {
+	if ld, ok := l.loading[key]; ok {
+		return ld
+	}
+
+	ctx, cancel := context.WithCancel(l.ctx)
+	ld := &lazyLoad[T]{cancel: cancel, done: make(chan struct{})}
+	l.loading[key] = ld
+
+	from, to := key.from, key.to
+
+	go func() {
+		items, err := l.src.Load(ctx, from, to)
+		if err == nil {
+			err = ctx.Err()
+		}
+		if err == nil && len(items) != to-from {
+			err = fmt.Errorf("pager: source returned %d items for [%d:%d]", len(items), from, to)
+		}
+
+		l.mu.Lock()
+		if l.loading[key] == ld {
+			delete(l.loading, key)
+		}
+		if err == nil {
+			l.put(key, items)
+		}
+		ld.items, ld.err = items, err
+		l.mu.Unlock()
+
+		cancel()
+		close(ld.done)
+	}()
+
+	return ld
+}
+
+// put adds the items of the given block to the cache.
+// l.mu must be held.
+func (l *Lazy[T]) put(key lazyKey, items []T) {
+	if el, ok := l.cache[key]; ok {
+		el.Value.(*lazyBlock[T]).items = items
+		l.lru.MoveToFront(el)
+		return
+	}
+
+	l.cache[key] = l.lru.PushFront(&lazyBlock[T]{key, items})
+
+	for l.lru.Len() > l.cacheSize {
+		el := l.lru.Back()
+		l.lru.Remove(el)
+		delete(l.cache, el.Value.(*lazyBlock[T]).key)
+	}
+}
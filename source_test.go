@@ -0,0 +1,244 @@
+package pager
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+type testSource struct {
+	mu      sync.Mutex
+	loads   [][2]int
+	loaded  chan [2]int
+	block   map[int]bool
+	aborted chan [2]int
+}
+
+func newTestSource() *testSource {
+	return &testSource{
+		loaded:  make(chan [2]int, 100),
+		block:   map[int]bool{},
+		aborted: make(chan [2]int, 100),
+	}
+}
+
+func (s *testSource) Len() int {
+	return len(data)
+}
+
+func (s *testSource) Load(ctx context.Context, from, to int) ([]string, error) {
+	s.mu.Lock()
+	s.loads = append(s.loads, [2]int{from, to})
+	block := s.block[from]
+	s.mu.Unlock()
+
+	if block {
+		<-ctx.Done()
+		s.aborted <- [2]int{from, to}
+		return nil, ctx.Err()
+	}
+
+	s.loaded <- [2]int{from, to}
+	return data[from:to], nil
+}
+
+func (s *testSource) numLoads() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.loads)
+}
+
+// waitFor waits for the given loads in any order.
+func waitFor(t *testing.T, ch chan [2]int, want ...[2]int) {
+	t.Helper()
+
+	pending := map[[2]int]bool{}
+	for _, w := range want {
+		pending[w] = true
+	}
+
+	for len(pending) > 0 {
+		select {
+		case got := <-ch:
+			if !pending[got] {
+				t.Fatalf("got load of %v; want %v", got, want)
+			}
+			delete(pending, got)
+		case <-time.After(time.Second):
+			t.Fatalf("no load of %v", pending)
+		}
+	}
+}
+
+func TestLazyVisible(t *testing.T) {
+	tests := []struct {
+		opts     []Option
+		next     int
+		items    []string
+		selected int
+	}{
+		{nil, 0, []string{"one", "two", "three"}, 0},
+		{nil, 4, []string{"four", "five", "six"}, 1},
+		{nil, 9, []string{"ten"}, 0},
+		{[]Option{Top()}, 4, []string{"five", "six", "seven"}, 0},
+		{[]Option{Bottom()}, 4, []string{"three", "four", "five"}, 2},
+	}
+
+	for _, test := range tests {
+		l := NewLazy[string](newTestSource(), 3, 4, test.opts...)
+
+		for i := 0; i < test.next; i++ {
+			l.Next()
+		}
+
+		items, selected, err := l.Visible(context.Background())
+		l.Close()
+
+		if err != nil {
+			t.Errorf("%v times Next(); err = %v", test.next, err)
+			continue
+		}
+
+		if got, want := items, test.items; !reflect.DeepEqual(got, want) {
+			t.Errorf("%v times Next(); items = %v; want %v", test.next, got, want)
+		}
+
+		if got, want := selected, test.selected; got != want {
+			t.Errorf("%v times Next(); selected = %v; want %v", test.next, got, want)
+		}
+	}
+}
+
+func TestLazyCache(t *testing.T) {
+	src := newTestSource()
+	l := NewLazy[string](src, 4, 2)
+	defer l.Close()
+
+	ctx := context.Background()
+
+	l.Visible(ctx)
+	waitFor(t, src.loaded, [2]int{0, 4})
+
+	l.Next()
+	l.Visible(ctx)
+
+	if got, want := src.numLoads(), 1; got != want {
+		t.Errorf("after Next(); loads = %v; want %v", got, want)
+	}
+
+	// the next block is prefetched, since the last row is selected
+	l.PageDown()
+	l.Visible(ctx)
+	waitFor(t, src.loaded, [2]int{4, 8}, [2]int{8, 10})
+
+	l.PageDown()
+	l.Visible(ctx)
+
+	// the first block has been evicted
+	l.PageUp()
+	l.PageUp()
+	l.Visible(ctx)
+	waitFor(t, src.loaded, [2]int{0, 4})
+
+	if got, want := src.numLoads(), 4; got != want {
+		t.Errorf("loads = %v; want %v", got, want)
+	}
+}
+
+func TestLazyPrefetch(t *testing.T) {
+	src := newTestSource()
+	l := NewLazy[string](src, 4, 4)
+	defer l.Close()
+
+	ctx := context.Background()
+
+	l.Visible(ctx)
+	waitFor(t, src.loaded, [2]int{0, 4})
+
+	l.Next()
+	l.Next()
+	l.Next()
+	l.Visible(ctx)
+
+	// the next block is prefetched
+	waitFor(t, src.loaded, [2]int{4, 8})
+
+	l.Next()
+	items, _, _ := l.Visible(ctx)
+
+	if got, want := items, []string{"five", "six", "seven", "eight"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("items = %v; want %v", got, want)
+	}
+
+	// the previous block is cached, the next one is not needed yet
+	if got, want := src.numLoads(), 2; got != want {
+		t.Errorf("loads = %v; want %v", got, want)
+	}
+}
+
+func TestLazyCancel(t *testing.T) {
+	src := newTestSource()
+	src.block[3] = true
+
+	l := NewLazy[string](src, 3, 4)
+	defer l.Close()
+
+	l.PageDown()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	_, _, err := l.Visible(ctx)
+	cancel()
+
+	if got, want := err, context.DeadlineExceeded; got != want {
+		t.Fatalf("err = %v; want %v", got, want)
+	}
+
+	// scrolling past the loading block cancels its load
+	l.PageDown()
+	items, _, err := l.Visible(context.Background())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := items, []string{"seven", "eight", "nine"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("items = %v; want %v", got, want)
+	}
+
+	waitFor(t, src.aborted, [2]int{3, 6})
+}
+
+func TestLazyResize(t *testing.T) {
+	l := NewLazy[string](newTestSource(), 3, 4)
+	defer l.Close()
+
+	if _, _, err := l.Visible(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	Resize(l, 5)
+
+	items, _, err := l.Visible(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := items, []string{"one", "two", "three", "four", "five"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("after Resize(5); items = %v; want %v", got, want)
+	}
+
+	Resize(l, 2)
+	Last(l)
+	SetLen(l, 9)
+
+	items, _, err = l.Visible(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := items, []string{"nine"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("after SetLen(9); items = %v; want %v", got, want)
+	}
+}
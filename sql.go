@@ -0,0 +1,115 @@
+package pager
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// LimitOffset returns the arguments for a `LIMIT ? OFFSET ?` clause
+// that selects the items that are shown by the pager.
+// If there is no data to be shown, limit is 0.
+func LimitOffset(pg Pager) (limit, offset int) {
+	from, to, _ := pg.Indexes()
+	if from == -1 {
+		return 0, 0
+	}
+	return to - from, from
+}
+
+// Dialect is a SQL dialect, defining the placeholders.
+type Dialect int
+
+const (
+	// PostgreSQL uses the placeholders $1, $2, ...
+	PostgreSQL Dialect = iota
+
+	// MySQL uses the placeholder ?
+	MySQL
+
+	// SQLite uses the placeholder ?
+	SQLite
+)
+
+// Placeholder returns the placeholder of the n-th argument (starting with 1).
+func (d Dialect) Placeholder(n int) string {
+	if d == PostgreSQL {
+		return "$" + strconv.Itoa(n)
+	}
+	return "?"
+}
+
+// Keyset describes a keyset (seek) pagination over the given ordering columns.
+// The combination of the columns must be unique, e.g. by having the primary key
+// as the last column. All columns are ordered in the same direction.
+type Keyset struct {
+	// Columns are the ordering columns. They are not quoted.
+	Columns []string
+
+	// Desc orders the columns descending.
+	Desc bool
+
+	// Dialect defines the placeholders.
+	Dialect Dialect
+
+	// ArgOffset is the number of arguments that precede the clause
+	// within the query. It is only relevant for numbered placeholders.
+	ArgOffset int
+}
+
+// Clause returns the clause for the page after the row with the given values
+// of the ordering columns and the according arguments, e.g.
+//
+//	WHERE (a, b) > ($1, $2) ORDER BY a, b LIMIT $3
+//
+// For the first page, last must be empty and the WHERE part is omitted.
+// Otherwise last must have a value for each column.
+// An error is returned, if there are no columns or the values don't match them.
+func (k Keyset) Clause(last []any, limit int) (clause string, args []any, err error) {
+	switch {
+	case len(k.Columns) == 0:
+		return "", nil, fmt.Errorf("pager: keyset without columns")
+	case len(last) > 0 && len(last) != len(k.Columns):
+		return "", nil, fmt.Errorf("pager: %d keyset values for %d columns", len(last), len(k.Columns))
+	}
+
+	var bf strings.Builder
+	n := k.ArgOffset
+
+	if len(last) > 0 {
+		op := " > "
+		if k.Desc {
+			op = " < "
+		}
+
+		bf.WriteString("WHERE (")
+		bf.WriteString(strings.Join(k.Columns, ", "))
+		bf.WriteString(")" + op + "(")
+		for i, v := range last {
+			if i > 0 {
+				bf.WriteString(", ")
+			}
+			n++
+			bf.WriteString(k.Dialect.Placeholder(n))
+			args = append(args, v)
+		}
+		bf.WriteString(") ")
+	}
+
+	bf.WriteString("ORDER BY ")
+	for i, col := range k.Columns {
+		if i > 0 {
+			bf.WriteString(", ")
+		}
+		bf.WriteString(col)
+		if k.Desc {
+			bf.WriteString(" DESC")
+		}
+	}
+
+	n++
+	bf.WriteString(" LIMIT " + k.Dialect.Placeholder(n))
+	args = append(args, limit)
+
+	return bf.String(), args, nil
+}
@@ -0,0 +1,102 @@
+package pager
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLimitOffset(t *testing.T) {
+	tests := []struct {
+		pager         Pager
+		limit, offset int
+	}{
+		{New(3, 10), 3, 0},
+		{New(3, 10, PreSelect(4)), 3, 3},
+		{New(3, 10, PreSelect(9)), 1, 9},
+		{New(3, 10, Top(), PreSelect(8)), 2, 8},
+		{New(3, 0), 0, 0},
+		{New(3, 10, Groups(customers(2, 2, 2, 2, 2)), PreSelect(3)), 2, 2},
+	}
+
+	for i, test := range tests {
+		limit, offset := LimitOffset(test.pager)
+
+		if limit != test.limit || offset != test.offset {
+			t.Errorf("[%v] LimitOffset() = %v, %v; want %v, %v", i, limit, offset, test.limit, test.offset)
+		}
+	}
+}
+
+func TestKeysetClause(t *testing.T) {
+	tests := []struct {
+		keyset Keyset
+		last   []any
+		clause string
+		args   []any
+	}{
+		{
+			Keyset{Columns: []string{"created", "id"}},
+			nil,
+			"ORDER BY created, id LIMIT $1",
+			[]any{20},
+		},
+		{
+			Keyset{Columns: []string{"created", "id"}},
+			[]any{"2018-01-02", 42},
+			"WHERE (created, id) > ($1, $2) ORDER BY created, id LIMIT $3",
+			[]any{"2018-01-02", 42, 20},
+		},
+		{
+			Keyset{Columns: []string{"created", "id"}, Desc: true, ArgOffset: 1},
+			[]any{"2018-01-02", 42},
+			"WHERE (created, id) < ($2, $3) ORDER BY created DESC, id DESC LIMIT $4",
+			[]any{"2018-01-02", 42, 20},
+		},
+		{
+			Keyset{Columns: []string{"id"}, Dialect: MySQL},
+			[]any{42},
+			"WHERE (id) > (?) ORDER BY id LIMIT ?",
+			[]any{42, 20},
+		},
+		{
+			Keyset{Columns: []string{"name", "id"}, Dialect: SQLite},
+			[]any{"x", 42},
+			"WHERE (name, id) > (?, ?) ORDER BY name, id LIMIT ?",
+			[]any{"x", 42, 20},
+		},
+	}
+
+	for _, test := range tests {
+		clause, args, err := test.keyset.Clause(test.last, 20)
+		if err != nil {
+			t.Errorf("Clause(%v); err = %v", test.last, err)
+			continue
+		}
+
+		if got, want := clause, test.clause; got != want {
+			t.Errorf("Clause(%v); clause = %q; want %q", test.last, got, want)
+		}
+
+		if got, want := args, test.args; !reflect.DeepEqual(got, want) {
+			t.Errorf("Clause(%v); args = %v; want %v", test.last, got, want)
+		}
+	}
+}
+
+func TestKeysetClauseErrors(t *testing.T) {
+	tests := []struct {
+		keyset Keyset
+		last   []any
+	}{
+		{Keyset{}, nil},
+		{Keyset{}, []any{42}},
+		{Keyset{Columns: []string{"created", "id"}}, []any{42}},
+		{Keyset{Columns: []string{"id"}}, []any{"x", 42}},
+	}
+
+	for _, test := range tests {
+		if _, _, err := test.keyset.Clause(test.last, 20); err == nil {
+			t.Errorf("%+v.Clause(%v); err = nil", test.keyset, test.last)
+		}
+	}
+}
@@ -0,0 +1,1078 @@
+package pager
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+)
+
+// Style is the display style of a pager, see the according options.
+type Style uint8
+
+const (
+	// StyleFixPage always keeps the same pages (default).
+	StyleFixPage Style = iota
+
+	// StyleTop keeps the selected line at the top.
+	StyleTop
+
+	// StyleBottom keeps the selected line at the bottom.
+	StyleBottom
+)
+
+// CursorMode is the placement of the selection on PageDown and PageUp,
+// see the PageCursor option.
+type CursorMode uint8
+
+const (
+	// CursorDefault selects the last row of the next page on PageDown and
+	// keeps the row on PageUp.
+	CursorDefault CursorMode = iota
+
+	// CursorKeepRow keeps the row of the selection within the viewport.
+	CursorKeepRow
+
+	// CursorFirstRow selects the first row of the new viewport.
+	CursorFirstRow
+
+	// CursorLastRow selects the last row of the new viewport.
+	CursorLastRow
+
+	// CursorEdge selects the last (PageDown) or first (PageUp) row of the
+	// viewport, if it is not selected yet. Otherwise the viewport is moved and
+	// the according row of the new viewport is selected, like list boxes do on Windows.
+	CursorEdge
+)
+
+// The styles only apply to moves of the selection: ScrollDown and ScrollUp
+// move the viewport independently of the selection, see Reduce.
+
+// State is the state of a pager. It is a plain value that is changed via Reduce,
+// so that states can be kept for undo, replay and debugging.
+// A pager is restored from a state via FromState.
+type State struct {
+	Height int `json:"height"`
+	Len    int `json:"len"`
+
+	// Selected is the index of the selected item within the data.
+	// It is -1 if there is no data.
+	Selected int `json:"selected"`
+
+	Style Style `json:"style"`
+
+	// Offset is the index of the first shown item within the data.
+	Offset int `json:"offset"`
+
+	// NoSelection is true for pagers without selection, see the NoSelection option.
+	// Selected is always -1 then.
+	NoSelection bool `json:"noSelection,omitempty"`
+
+	// Cursor is the placement of the selection on page moves, see PageCursor.
+	Cursor CursorMode `json:"cursor,omitempty"`
+
+	// Overlap is the number of rows of the previous page that are kept
+	// by page moves, see PageOverlap.
+	Overlap int `json:"overlap,omitempty"`
+
+	// Advance is the fraction of the height that page moves advance,
+	// see PageAdvance. It takes precedence over Overlap.
+	Advance float64 `json:"advance,omitempty"`
+
+	// Header and Footer are the number of fixed rows, see the Header and
+	// Footer options. Height is the number of the remaining scrolling rows.
+	Header int `json:"header,omitempty"`
+	Footer int `json:"footer,omitempty"`
+
+	// GroupStarts are the first indexes of the groups, if the items are grouped.
+	// They are maintained by the pager, see Groups.
+	GroupStarts []int `json:"groupStarts,omitempty"`
+
+	// PageStarts are the first indexes of the pages of grouped items.
+	// They are computed from GroupStarts and Height, see Pages.
+	PageStarts []int `json:"pageStarts,omitempty"`
+
+	// Sticky is true, if there is a sticky group row, see StickyGroups.
+	Sticky bool `json:"sticky,omitempty"`
+
+	// Margin is the number of rows that are kept between the selection and
+	// the edges of the viewport when scrolling, see ScrollMargin.
+	Margin int `json:"margin,omitempty"`
+
+	// Stream is true for the pagers of streams, see the Stream option.
+	Stream bool `json:"stream,omitempty"`
+
+	// Complete is true, if the end of the stream has been reached.
+	Complete bool `json:"complete,omitempty"`
+
+	// Requested is the length that has last been requested from a stream, or -1.
+	Requested int `json:"requested,omitempty"`
+
+	// Marks are the named marks, sorted by name. See SetMark.
+	Marks []Mark `json:"marks,omitempty"`
+}
+
+// Op is the operation of an Action.
+type Op uint8
+
+const (
+	// OpNext selects the next item, see ActNext.
+	OpNext Op = iota + 1
+
+	// OpPrev selects the previous item, see ActPrev.
+	OpPrev
+
+	// OpPageDown selects the next page, see ActPageDown.
+	OpPageDown
+
+	// OpPageUp selects the previous page, see ActPageUp.
+	OpPageUp
+
+	// OpFirst selects the first item, see ActFirst.
+	OpFirst
+
+	// OpLast selects the last item, see ActLast.
+	OpLast
+
+	// OpSelect selects the item with the index N, see ActSelect.
+	OpSelect
+
+	// OpResize changes the height to N, see ActResize.
+	OpResize
+
+	// OpSetLen changes the length of the data to N, see ActSetLen.
+	OpSetLen
+
+	// OpAppend extends the data by N items, see ActAppend.
+	OpAppend
+
+	// OpComplete marks the end of a stream, see ActComplete.
+	OpComplete
+
+	// OpInsert inserts N items at the index At, see ActInsert.
+	OpInsert
+
+	// OpDelete deletes N items at the index At, see ActDelete.
+	OpDelete
+
+	// OpSetMark sets the mark Name to the selected item, see ActSetMark.
+	OpSetMark
+
+	// OpJumpToMark selects the item of the mark Name, see ActJumpToMark.
+	OpJumpToMark
+
+	// OpDeleteMark deletes the mark Name, see ActDeleteMark.
+	OpDeleteMark
+
+	// OpScrollDown moves the viewport N rows down, see ActScrollDown.
+	OpScrollDown
+
+	// OpScrollUp moves the viewport N rows up, see ActScrollUp.
+	OpScrollUp
+
+	// OpScrollTo scrolls the item with the index N to Align, see ActScrollTo.
+	OpScrollTo
+
+	// OpNextGroup selects the first item of the next group, see ActNextGroup.
+	OpNextGroup
+
+	// OpPrevGroup selects the first item of the group or of the previous group, see ActPrevGroup.
+	OpPrevGroup
+)
+
+// Action is an action on a pager state, see Reduce.
+// N, At, Name and Align are the arguments of the operation, if it has them.
+type Action struct {
+	Op    Op     `json:"op"`
+	N     int    `json:"n,omitempty"`
+	At    int    `json:"at,omitempty"`
+	Name  string `json:"name,omitempty"`
+	Align Align  `json:"align,omitempty"`
+}
+
+var opNames = map[Op]string{
+	OpNext:       "next",
+	OpPrev:       "prev",
+	OpPageDown:   "pagedown",
+	OpPageUp:     "pageup",
+	OpFirst:      "first",
+	OpLast:       "last",
+	OpSelect:     "select",
+	OpResize:     "resize",
+	OpSetLen:     "setlen",
+	OpAppend:     "append",
+	OpComplete:   "complete",
+	OpInsert:     "insert",
+	OpDelete:     "delete",
+	OpSetMark:    "setmark",
+	OpJumpToMark: "jumpmark",
+	OpDeleteMark: "delmark",
+	OpScrollDown: "scrolldown",
+	OpScrollUp:   "scrollup",
+	OpScrollTo:   "scrollto",
+	OpNextGroup:  "nextgroup",
+	OpPrevGroup:  "prevgroup",
+}
+
+// argument kinds of operations
+const (
+	argNone = iota
+	argN
+	argAtN
+	argName
+	argNAlign
+)
+
+// args returns the kind of arguments of the operation.
+func (o Op) args() int {
+	switch o {
+	case OpSelect, OpResize, OpSetLen, OpAppend, OpScrollDown, OpScrollUp:
+		return argN
+	case OpInsert, OpDelete:
+		return argAtN
+	case OpSetMark, OpJumpToMark, OpDeleteMark:
+		return argName
+	case OpScrollTo:
+		return argNAlign
+	}
+	return argNone
+}
+
+// String returns the name of the operation.
+func (o Op) String() string {
+	if name, ok := opNames[o]; ok {
+		return name
+	}
+	return "op" + strconv.Itoa(int(o))
+}
+
+// MarshalText returns the name of the operation.
+func (o Op) MarshalText() ([]byte, error) {
+	if _, ok := opNames[o]; !ok {
+		return nil, fmt.Errorf("pager: unknown operation %d", o)
+	}
+	return []byte(o.String()), nil
+}
+
+// UnmarshalText sets the operation of the given name.
+func (o *Op) UnmarshalText(text []byte) error {
+	for op, name := range opNames {
+		if name == string(text) {
+			*o = op
+			return nil
+		}
+	}
+	return fmt.Errorf("pager: unknown operation %q", text)
+}
+
+// String returns the action in the form "op", "op n", "op at n", "op name" or "op n align".
+func (a Action) String() string {
+	switch a.Op.args() {
+	case argNAlign:
+		return a.Op.String() + " " + strconv.Itoa(a.N) + " " + a.Align.String()
+	case argN:
+		return a.Op.String() + " " + strconv.Itoa(a.N)
+	case argAtN:
+		return a.Op.String() + " " + strconv.Itoa(a.At) + " " + strconv.Itoa(a.N)
+	case argName:
+		return a.Op.String() + " " + strconv.Quote(a.Name)
+	}
+	return a.Op.String()
+}
+
+// ActNext is the action to select the next item.
+func ActNext() Action { return Action{Op: OpNext} }
+
+// ActPrev is the action to select the previous item.
+func ActPrev() Action { return Action{Op: OpPrev} }
+
+// ActPageDown is the action to select the next page.
+func ActPageDown() Action { return Action{Op: OpPageDown} }
+
+// ActPageUp is the action to select the previous page.
+func ActPageUp() Action { return Action{Op: OpPageUp} }
+
+// ActFirst is the action to select the first item.
+func ActFirst() Action { return Action{Op: OpFirst} }
+
+// ActLast is the action to select the last item.
+func ActLast() Action { return Action{Op: OpLast} }
+
+// ActSelect is the action to select the item with the given index within the data.
+func ActSelect(index int) Action { return Action{Op: OpSelect, N: index} }
+
+// ActResize is the action to change the height, including the fixed rows.
+func ActResize(height int) Action { return Action{Op: OpResize, N: height} }
+
+// ActSetLen is the action to change the length of the data.
+func ActSetLen(n int) Action { return Action{Op: OpSetLen, N: n} }
+
+// ActAppend is the action to extend the data by n items.
+func ActAppend(n int) Action { return Action{Op: OpAppend, N: n} }
+
+// ActComplete is the action to mark the end of a stream.
+func ActComplete() Action { return Action{Op: OpComplete} }
+
+// ActInsert is the action to insert n items at the given index of the data.
+func ActInsert(at, n int) Action { return Action{Op: OpInsert, At: at, N: n} }
+
+// ActDelete is the action to delete n items at the given index of the data.
+func ActDelete(at, n int) Action { return Action{Op: OpDelete, At: at, N: n} }
+
+// ActSetMark is the action to set the mark of the given name to the selected item.
+func ActSetMark(name string) Action { return Action{Op: OpSetMark, Name: name} }
+
+// ActJumpToMark is the action to select the item of the mark with the given name.
+func ActJumpToMark(name string) Action { return Action{Op: OpJumpToMark, Name: name} }
+
+// ActDeleteMark is the action to delete the mark of the given name.
+func ActDeleteMark(name string) Action { return Action{Op: OpDeleteMark, Name: name} }
+
+// ActNextGroup is the action to select the first item of the next group.
+func ActNextGroup() Action { return Action{Op: OpNextGroup} }
+
+// ActPrevGroup is the action to select the first item of the group,
+// or of the previous group, if the first item is selected.
+func ActPrevGroup() Action { return Action{Op: OpPrevGroup} }
+
+// ActScrollDown is the action to move the viewport n rows down.
+func ActScrollDown(n int) Action { return Action{Op: OpScrollDown, N: n} }
+
+// ActScrollUp is the action to move the viewport n rows up.
+func ActScrollUp(n int) Action { return Action{Op: OpScrollUp, N: n} }
+
+// ActScrollTo is the action to select the item with the given index
+// and to scroll it to the given alignment.
+func ActScrollTo(index int, align Align) Action {
+	return Action{Op: OpScrollTo, N: index, Align: align}
+}
+
+// Align is the alignment of an item within the viewport, see ScrollTo.
+type Align uint8
+
+const (
+	// AlignTop shows the item in the first row.
+	AlignTop Align = iota
+
+	// AlignCenter shows the item in the middle row.
+	AlignCenter
+
+	// AlignBottom shows the item in the last row.
+	AlignBottom
+
+	// AlignNearest scrolls as little as possible to show the item.
+	AlignNearest
+)
+
+var alignNames = [...]string{"top", "center", "bottom", "nearest"}
+
+// String returns the name of the alignment.
+func (a Align) String() string {
+	if int(a) < len(alignNames) {
+		return alignNames[a]
+	}
+	return "align" + strconv.Itoa(int(a))
+}
+
+// MarshalText returns the name of the alignment.
+func (a Align) MarshalText() ([]byte, error) {
+	if int(a) >= len(alignNames) {
+		return nil, fmt.Errorf("pager: unknown alignment %d", a)
+	}
+	return []byte(a.String()), nil
+}
+
+// UnmarshalText sets the alignment of the given name.
+func (a *Align) UnmarshalText(text []byte) error {
+	for i, name := range alignNames {
+		if name == string(text) {
+			*a = Align(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("pager: unknown alignment %q", text)
+}
+
+// Effect describes the consequences of an action.
+type Effect uint8
+
+const (
+	// EffectChanged is set, if the selected item has changed.
+	EffectChanged Effect = 1 << iota
+
+	// EffectNeedMore is set, if more items of a stream are needed.
+	EffectNeedMore
+
+	// EffectScrolled is set, if the viewport has moved.
+	EffectScrolled
+)
+
+// Changed returns wether the selected item has changed.
+func (e Effect) Changed() bool {
+	return e&EffectChanged != 0
+}
+
+// NeedMore returns wether more items of a stream are needed.
+func (e Effect) NeedMore() bool {
+	return e&EffectNeedMore != 0
+}
+
+// Scrolled returns wether the viewport has moved.
+func (e Effect) Scrolled() bool {
+	return e&EffectScrolled != 0
+}
+
+// NewState returns the initial state of a pager, see New.
+// A height below 1 is treated as 1.
+func NewState(height, dataLen int, style Style) State {
+	s := State{Height: max(height, 1), Len: dataLen, Style: style, Requested: -1}
+	if dataLen == 0 {
+		s.Selected = -1
+	}
+	return s
+}
+
+// Reduce applies the action to the given state and returns the new state
+// together with the effect of the action. The given state is not modified.
+//
+// After the selection, the length or the height has changed, the viewport
+// follows the selection according to the style. ScrollDown and ScrollUp move
+// the viewport and only drag the selection along, if it would leave the viewport.
+//
+// Reduce doesn't know the functions of the Groups and MarkKeys options.
+// Inserted and appended items are treated as a new group, until the pager
+// regroups them, and keyed marks beyond the length are not found, until
+// the pager resolves them.
+func Reduce(s State, a Action) (State, Effect) {
+	s.Height = max(s.Height, 1)
+
+	if s.NoSelection {
+		return reduceView(s, a)
+	}
+
+	old, oldLen, oldOffset := s.Selected, s.Len, s.Offset
+
+	switch a.Op {
+	case OpNext:
+		if s.Len-1 > s.Selected {
+			s.Selected++
+		}
+	case OpPrev:
+		if s.Selected > 0 {
+			s.Selected--
+		}
+	case OpPageDown:
+		if step := s.pageStep(); s.Cursor != CursorDefault {
+			s = s.pageMove(1)
+		} else if step < s.Height {
+			s = s.pageBy(step)
+		} else {
+			s = s.pageDown()
+		}
+	case OpPageUp:
+		if step := s.pageStep(); s.Cursor != CursorDefault {
+			s = s.pageMove(-1)
+		} else if step < s.Height {
+			s = s.pageBy(-step)
+		} else {
+			s = s.pageUp()
+		}
+	case OpFirst:
+		s = s.selectIndex(0)
+	case OpLast:
+		s = s.selectIndex(s.Len - 1)
+	case OpSelect:
+		s = s.selectIndex(a.N)
+	case OpResize:
+		if a.N > 0 {
+			s.Height = s.bodyHeight(a.N)
+			s.PageStarts = s.pageStarts()
+			s.Offset = s.offset()
+		}
+	case OpSetLen:
+		s = s.setLen(a.N)
+	case OpAppend:
+		if a.N > 0 {
+			s = s.setLen(s.Len + a.N)
+		}
+	case OpComplete:
+		s.Complete = true
+	case OpInsert:
+		s = s.insert(a.At, a.N)
+	case OpDelete:
+		s = s.delete(a.At, a.N)
+	case OpSetMark:
+		s = s.setMark(a.Name)
+	case OpJumpToMark:
+		if i, ok := s.Mark(a.Name); ok {
+			s = s.selectIndex(i)
+		}
+	case OpDeleteMark:
+		s = s.deleteMark(a.Name)
+	case OpScrollDown:
+		s = s.scroll(a.N)
+	case OpScrollUp:
+		s = s.scroll(-a.N)
+	case OpScrollTo:
+		s = s.reveal(a.N, a.Align)
+	case OpNextGroup:
+		if i, ok := s.nextGroup(s.Selected); ok {
+			s = s.selectIndex(i)
+		}
+	case OpPrevGroup:
+		if i, ok := s.prevGroup(s.Selected); ok {
+			s = s.selectIndex(i)
+		}
+	}
+
+	if s.Len != oldLen && len(s.GroupStarts) > 0 {
+		s = s.shiftGroups(a, oldLen)
+		if s.Style == StyleFixPage {
+			s.Offset = s.offset()
+		}
+	}
+
+	switch a.Op {
+	case OpScrollDown, OpScrollUp, OpScrollTo:
+	default:
+		if s.Selected != old || s.Len != oldLen {
+			s = s.follow()
+		}
+	}
+
+	var effect Effect
+	if s.Selected != old {
+		effect |= EffectChanged
+	}
+	if s.Offset != oldOffset {
+		effect |= EffectScrolled
+	}
+
+	switch a.Op {
+	case OpNext, OpPageDown, OpLast, OpSelect, OpJumpToMark, OpScrollTo, OpNextGroup:
+		if s.needMore(effect.Changed()) {
+			s.Requested = s.Len
+			effect |= EffectNeedMore
+		}
+	}
+
+	return s, effect
+}
+
+// reduceView is Reduce for states without selection. The moves scroll the viewport
+// like less does, so that the last page is always filled. EffectChanged is set,
+// if the viewport has moved.
+func reduceView(s State, a Action) (State, Effect) {
+	old, oldLen := s.Offset, s.Len
+
+	switch a.Op {
+	case OpNext:
+		s = s.scrollTo(s.Offset + 1)
+	case OpPrev:
+		s = s.scrollTo(s.Offset - 1)
+	case OpPageDown:
+		s = s.scrollTo(s.Offset + s.pageStep())
+	case OpPageUp:
+		s = s.scrollTo(s.Offset - s.pageStep())
+	case OpFirst:
+		s = s.scrollTo(0)
+	case OpLast:
+		s = s.scrollTo(s.Len)
+	case OpSelect:
+		s = s.scrollTo(a.N)
+	case OpScrollDown:
+		s = s.scrollTo(s.Offset + a.N)
+	case OpScrollUp:
+		s = s.scrollTo(s.Offset - a.N)
+	case OpScrollTo:
+		s = s.reveal(a.N, a.Align)
+	case OpNextGroup:
+		if i, ok := s.nextGroup(s.Offset); ok {
+			s = s.scrollTo(i)
+		}
+	case OpPrevGroup:
+		if i, ok := s.prevGroup(s.Offset); ok {
+			s = s.scrollTo(i)
+		}
+	case OpResize:
+		if a.N > 0 {
+			s.Height = s.bodyHeight(a.N)
+			s.PageStarts = s.pageStarts()
+		}
+		s = s.scrollTo(s.Offset)
+	case OpSetLen:
+		s = s.setLen(a.N).scrollTo(s.Offset)
+	case OpAppend:
+		if a.N > 0 {
+			s = s.setLen(s.Len + a.N)
+		}
+	case OpComplete:
+		s.Complete = true
+	case OpInsert:
+		n := s.Len
+		if s = s.insert(a.At, a.N); s.Len != n && n > 0 {
+			s = s.scrollTo(shiftInsert(s.Offset, a.At, a.N))
+		}
+	case OpDelete:
+		n := s.Len
+		if s = s.delete(a.At, a.N); s.Len != n {
+			if i, ok := shiftDelete(s.Offset, a.At, n-s.Len); ok {
+				s = s.scrollTo(i)
+			} else {
+				s = s.scrollTo(a.At)
+			}
+		}
+	case OpSetMark:
+		s = s.setMark(a.Name)
+	case OpJumpToMark:
+		if i, ok := s.Mark(a.Name); ok {
+			s = s.scrollTo(i)
+		}
+	case OpDeleteMark:
+		s = s.deleteMark(a.Name)
+	}
+	s.Selected = -1
+
+	if s.Len != oldLen && len(s.GroupStarts) > 0 {
+		s = s.shiftGroups(a, oldLen)
+	}
+
+	var effect Effect
+	if s.Offset != old {
+		effect |= EffectChanged | EffectScrolled
+	}
+
+	switch a.Op {
+	case OpNext, OpPageDown, OpLast, OpSelect, OpJumpToMark, OpScrollDown, OpScrollTo, OpNextGroup:
+		if s.needMore(effect.Changed()) {
+			s.Requested = s.Len
+			effect |= EffectNeedMore
+		}
+	}
+
+	return s, effect
+}
+
+// scrollTo sets the offset, clamped so that the last page is filled.
+func (s State) scrollTo(offset int) State {
+	s.Offset = max(min(offset, s.Len-s.Height), 0)
+	return s
+}
+
+// reveal selects the item with the given index (unless there is no selection)
+// and scrolls it to the given alignment, without running past the ends of the data.
+func (s State) reveal(i int, align Align) State {
+	if s.Len == 0 {
+		return s
+	}
+
+	i = max(min(i, s.Len-1), 0)
+	if !s.NoSelection {
+		s.Selected = i
+	}
+
+	switch align {
+	case AlignCenter:
+		return s.scrollTo(i - (s.Height-1)/2)
+	case AlignBottom:
+		return s.scrollTo(i - s.Height + 1)
+	case AlignNearest:
+		switch {
+		case i < s.Offset:
+			return s.scrollTo(i)
+		case i >= s.Offset+s.Height:
+			return s.scrollTo(i - s.Height + 1)
+		}
+		return s
+	default:
+		return s.scrollTo(i)
+	}
+}
+
+// HasMore returns wether the state is a stream whose end has not been reached.
+func (s State) HasMore() bool {
+	return s.Stream && !s.Complete
+}
+
+// Indexes returns the from, to and selected index, see Pager.
+func (s State) Indexes() (from, to, selected int) {
+	if s.Len == 0 || s.Selected > s.Len-1 {
+		return -1, -1, -1
+	}
+
+	from, to = s.Offset, s.viewEnd()
+
+	if s.NoSelection {
+		return from, to, -1
+	}
+	return from, to, s.Selected - from
+}
+
+// offset returns the offset of the viewport for the selection, according to the style.
+func (s State) offset() int {
+	if s.Selected < 0 {
+		return 0
+	}
+
+	switch s.Style {
+	case StyleTop:
+		return s.Selected
+	case StyleBottom:
+		if s.Selected < s.Height {
+			return 0
+		}
+		return s.Selected - s.Height + 1
+	default:
+		_, from, _ := s.pageOf(s.Selected)
+		return from
+	}
+}
+
+// viewEnd returns the end of the viewport. With StyleFixPage, the pages of
+// grouped items may be shorter than the height.
+func (s State) viewEnd() int {
+	to := min(s.Offset+s.Height, s.Len)
+	if s.groupedPages() {
+		if _, from, end := s.pageOf(s.Offset); from == s.Offset {
+			to = min(to, end)
+		}
+	}
+	return to
+}
+
+// follow moves the viewport to the selection. With StyleFixPage the viewport
+// is only moved, if the selection is not shown.
+func (s State) follow() State {
+	if s.Style == StyleFixPage && s.Selected >= s.Offset && s.Selected < s.viewEnd() {
+		return s
+	}
+
+	s.Offset = s.offset()
+	return s
+}
+
+// scroll moves the viewport n rows down (or up, if n is negative).
+// It does not scroll beyond the last full viewport, so that the last page
+// of StyleFixPage may only be left upwards. The selection is dragged along,
+// keeping the margin to the edges of the viewport, except at the ends of the data.
+func (s State) scroll(n int) State {
+	if s.Len == 0 || n == 0 {
+		return s
+	}
+
+	offset := s.Offset + n
+	if last := max(s.Len-s.Height, 0); n > 0 && offset > last {
+		offset = max(last, s.Offset)
+	}
+	s.Offset = max(offset, 0)
+
+	margin := min(s.Margin, (s.Height-1)/2)
+	lo, hi := s.Offset+margin, s.Offset+s.Height-1-margin
+	if s.Offset == 0 {
+		lo = 0
+	}
+	if s.Offset+s.Height >= s.Len {
+		hi = s.Len - 1
+	}
+
+	s.Selected = min(max(s.Selected, lo), hi)
+	return s
+}
+
+func (s State) currentPage() (page int) {
+	if s.Selected < 0 {
+		return
+	}
+
+	page, _, _ = s.pageOf(s.Selected)
+	return
+}
+
+// pageOf returns the number and the range of the page that contains
+// the item with the given index, see Pages.
+func (s State) pageOf(i int) (page, from, to int) {
+	i = max(i, 0)
+	if len(s.GroupStarts) == 0 {
+		page = i / s.Height
+		from = page * s.Height
+		return page, from, min(from+s.Height, s.Len)
+	}
+
+	starts := s.PageStarts
+	if len(starts) == 0 {
+		return 0, 0, 0
+	}
+
+	page, found := slices.BinarySearch(starts, i)
+	if !found {
+		page--
+	}
+
+	from, to = starts[page], s.Len
+	if page+1 < len(starts) {
+		to = starts[page+1]
+	}
+	return page, from, to
+}
+
+// pageDown selects the last item of the next page.
+// On the last page, the selection is kept.
+func (s State) pageDown() State {
+	if s.Len == 0 || s.Selected < 0 {
+		return s
+	}
+
+	_, _, to := s.pageOf(s.Selected)
+	if to >= s.Len {
+		return s
+	}
+
+	_, _, next := s.pageOf(to)
+	s.Selected = next - 1
+	return s
+}
+
+// pageUp selects the item with the same position on the previous page.
+func (s State) pageUp() State {
+	page, from, _ := s.pageOf(s.Selected)
+	if s.Selected < 0 || page == 0 {
+		return s
+	}
+
+	_, prev, to := s.pageOf(from - 1)
+	s.Selected = min(prev+s.Selected-from, to-1)
+	return s
+}
+
+// pageStep returns the number of rows that page moves advance, see Overlap and Advance.
+func (s State) pageStep() int {
+	step := s.Height - s.Overlap
+	if s.Advance > 0 {
+		step = int(float64(s.Height) * s.Advance)
+	}
+	return max(min(step, s.Height), 1)
+}
+
+// pageBy moves the viewport and the selection by n rows, so that
+// the overlapping rows of the previous page stay visible.
+func (s State) pageBy(n int) State {
+	if s.Len == 0 {
+		return s
+	}
+
+	selected := max(min(s.Selected+n, s.Len-1), 0)
+	s = s.scroll(n)
+	s.Selected = max(min(selected, s.Offset+s.Height-1), s.Offset)
+	return s
+}
+
+// pageMove moves the viewport a page down (dir 1) or up (dir -1) and selects
+// a row of the new viewport according to the cursor mode. If the viewport
+// can't be moved, the last or first item is selected. The selection never
+// moves against the direction.
+func (s State) pageMove(dir int) State {
+	if s.Len == 0 {
+		return s
+	}
+
+	row := s.Selected - s.Offset
+	if s.Cursor == CursorEdge {
+		switch last := s.viewEnd() - s.Offset - 1; {
+		case dir > 0 && row < last:
+			s.Selected = s.Offset + last
+			return s
+		case dir < 0 && row > 0:
+			s.Selected = s.Offset
+			return s
+		}
+	}
+
+	// StyleTop and StyleBottom move the viewport to the selection afterwards,
+	// so the selection is moved by the page step instead of being placed on a row.
+	// Only the first page of StyleBottom has a free row, which is placed
+	// according to the mode, once the viewport moves up to it.
+	if s.Style != StyleFixPage {
+		selected := max(min(s.Selected+dir*s.pageStep(), s.Len-1), 0)
+		if s.Style == StyleBottom && dir < 0 && s.Offset > 0 && selected < s.Height {
+			switch rows := min(s.Height, s.Len); s.Cursor {
+			case CursorFirstRow, CursorEdge:
+				selected = 0
+			case CursorLastRow:
+				selected = rows - 1
+			default:
+				selected = min(row, rows-1)
+			}
+		}
+		s.Selected = selected
+		return s
+	}
+
+	offset := s.pageOffset(dir)
+	if offset == s.Offset {
+		s.Selected = 0
+		if dir > 0 {
+			s.Selected = s.Len - 1
+		}
+		return s
+	}
+
+	s.Offset = offset
+	rows := s.viewEnd() - offset
+	switch {
+	case s.Cursor == CursorFirstRow, s.Cursor == CursorEdge && dir < 0:
+		row = 0
+	case s.Cursor == CursorLastRow, s.Cursor == CursorEdge && dir > 0:
+		row = rows - 1
+	}
+
+	s.Selected = s.Offset + min(row, rows-1)
+	return s
+}
+
+// pageOffset returns the offset of the viewport a page down (dir 1) or up (dir -1).
+// With StyleFixPage the pages are kept, unless there is an overlap.
+func (s State) pageOffset(dir int) int {
+	step := s.pageStep()
+	if s.Style == StyleFixPage && step == s.Height {
+		if dir > 0 {
+			if _, _, to := s.pageOf(s.Offset); to < s.Len {
+				return to
+			}
+			return s.Offset
+		}
+
+		if s.Offset > 0 {
+			_, from, _ := s.pageOf(s.Offset - 1)
+			return from
+		}
+		return 0
+	}
+
+	if dir > 0 {
+		return max(min(s.Offset+step, s.Len-s.Height), s.Offset)
+	}
+	return max(s.Offset-step, 0)
+}
+
+func (s State) selectIndex(i int) State {
+	if s.Len == 0 {
+		return s
+	}
+
+	if i > s.Len-1 {
+		i = s.Len - 1
+	}
+	if i < 0 {
+		i = 0
+	}
+
+	s.Selected = i
+	return s
+}
+
+func (s State) setLen(n int) State {
+	if n < 0 {
+		n = 0
+	}
+	s.Len = n
+
+	switch {
+	case n == 0:
+		s.Selected = -1
+	case s.Selected == -1:
+		s.Selected = 0
+	case s.Selected > n-1:
+		s.Selected = n - 1
+	}
+
+	// keyed marks are resolved by the pager, see MarkKeys
+	var marks []Mark
+	for _, m := range s.Marks {
+		if m.Index < n || m.Key != "" {
+			marks = append(marks, m)
+		}
+	}
+	s.Marks = marks
+	return s
+}
+
+// insert inserts n items at the given index. The selection follows its item.
+func (s State) insert(at, n int) State {
+	if n <= 0 || at < 0 || at > s.Len {
+		return s
+	}
+
+	s.Len += n
+	switch {
+	case s.Selected == -1:
+		s.Selected = 0
+	case s.Selected >= at:
+		s.Selected += n
+	}
+
+	s.Marks = s.shiftMarks(func(i int) (int, bool) { return shiftInsert(i, at, n), true })
+	return s
+}
+
+// delete deletes n items at the given index. If the selected item is deleted,
+// the item after the deleted ones is selected.
+func (s State) delete(at, n int) State {
+	if at < 0 || at >= s.Len || n <= 0 {
+		return s
+	}
+	if at+n > s.Len {
+		n = s.Len - at
+	}
+
+	s.Len -= n
+	if s.Selected >= at {
+		if i, ok := shiftDelete(s.Selected, at, n); ok {
+			s.Selected = i
+		} else {
+			s.Selected = at
+		}
+	}
+	if s.Selected > s.Len-1 {
+		s.Selected = s.Len - 1
+	}
+
+	s.Marks = s.shiftMarks(func(i int) (int, bool) { return shiftDelete(i, at, n) })
+	return s
+}
+
+// shiftInsert returns the new index of the item with the index i
+// after inserting n items at the given index.
+func shiftInsert(i, at, n int) int {
+	if i >= at {
+		return i + n
+	}
+	return i
+}
+
+// shiftDelete returns the new index of the item with the index i after deleting
+// n items at the given index. ok is false, if the item has been deleted.
+func shiftDelete(i, at, n int) (_ int, ok bool) {
+	switch {
+	case i < at:
+		return i, true
+	case i < at+n:
+		return -1, false
+	default:
+		return i - n, true
+	}
+}
+
+// needMore returns wether more items of a stream are needed, because
+// the end of the loaded items is shown. Each length is only requested once,
+// unless a move failed at the end.
+func (s State) needMore(changed bool) bool {
+	if !s.HasMore() {
+		return false
+	}
+
+	_, to, _ := s.Indexes()
+	if s.Len > 0 && to != s.Len {
+		return false
+	}
+
+	return !changed || s.Requested != s.Len
+}
@@ -0,0 +1,147 @@
+package pager
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestReduce(t *testing.T) {
+	tests := []struct {
+		state    State
+		action   Action
+		selected int
+		effect   Effect
+	}{
+		{NewState(3, 10, StyleFixPage), ActNext(), 1, EffectChanged},
+		{NewState(3, 10, StyleFixPage), ActPrev(), 0, 0},
+		{NewState(3, 10, StyleFixPage), ActPageDown(), 5, EffectChanged | EffectScrolled},
+		{NewState(3, 10, StyleFixPage), ActLast(), 9, EffectChanged | EffectScrolled},
+		{NewState(3, 10, StyleFixPage), ActSelect(4), 4, EffectChanged | EffectScrolled},
+		{NewState(3, 10, StyleFixPage), ActSelect(42), 9, EffectChanged | EffectScrolled},
+		{NewState(3, 10, StyleFixPage), ActSelect(-1), 0, 0},
+		{NewState(3, 10, StyleFixPage), ActSetLen(0), -1, EffectChanged},
+		{NewState(3, 0, StyleFixPage), ActSetLen(5), 0, EffectChanged},
+		{NewState(3, 0, StyleFixPage), ActAppend(5), 0, EffectChanged},
+		{NewState(3, 0, StyleFixPage), ActSelect(3), -1, 0},
+		{State{Height: 3, Len: 10, Selected: 9, Offset: 9}, ActSetLen(5), 4, EffectChanged | EffectScrolled},
+		{State{Height: 3, Len: 10, Selected: 9, Offset: 9}, ActFirst(), 0, EffectChanged | EffectScrolled},
+		{State{Height: 3, Len: 10, Selected: 9, Offset: 9}, ActResize(5), 9, EffectScrolled},
+		{State{Height: 3, Len: 10, Selected: 4, Offset: 3}, ActScrollDown(1), 4, EffectScrolled},
+		{State{Height: 3, Len: 10, Selected: 4, Offset: 3}, ActScrollDown(2), 5, EffectChanged | EffectScrolled},
+		{State{Height: 3, Len: 10, Selected: 4, Offset: 3}, ActScrollUp(9), 2, EffectChanged | EffectScrolled},
+		{State{Height: 3, Len: 10, Selected: 9, Offset: 7}, ActScrollDown(1), 9, 0},
+		{NewState(3, 10, StyleFixPage), ActScrollTo(4, AlignCenter), 4, EffectChanged | EffectScrolled},
+		{NewState(3, 10, StyleFixPage), ActScrollTo(2, AlignNearest), 2, EffectChanged},
+		{State{Height: 3, Len: 3, Stream: true, Requested: -1}, ActNext(), 1, EffectChanged | EffectNeedMore},
+		{State{Height: 3, Len: 3, Stream: true, Requested: 3}, ActNext(), 1, EffectChanged},
+		{State{Height: 3, Len: 3, Selected: 2, Stream: true, Requested: 3}, ActNext(), 2, EffectNeedMore},
+		{State{Height: 3, Len: 3, Selected: 2, Stream: true, Complete: true}, ActNext(), 2, 0},
+	}
+
+	for _, test := range tests {
+		before := test.state
+		state, effect := Reduce(test.state, test.action)
+
+		if got, want := state.Selected, test.selected; got != want {
+			t.Errorf("Reduce(%+v, %+v); selected = %v; want %v", test.state, test.action, got, want)
+		}
+
+		if got, want := effect, test.effect; got != want {
+			t.Errorf("Reduce(%+v, %+v); effect = %v; want %v", test.state, test.action, got, want)
+		}
+
+		if !reflect.DeepEqual(test.state, before) {
+			t.Errorf("Reduce(%+v, %+v) modified the given state", test.state, test.action)
+		}
+	}
+}
+
+func TestReduceResize(t *testing.T) {
+	state, _ := Reduce(State{Height: 3, Len: 10, Selected: 4}, ActResize(5))
+
+	from, to, selected := state.Indexes()
+	if from != 0 || to != 5 || selected != 4 {
+		t.Errorf("from: %v, to: %v, selected: %v", from, to, selected)
+	}
+
+	if state, _ = Reduce(state, ActResize(0)); state.Height != 5 {
+		t.Errorf("ActResize(0); Height = %v; want 5", state.Height)
+	}
+}
+
+func TestReduceZeroHeight(t *testing.T) {
+	state, _ := Reduce(State{Len: 5}, ActNext())
+
+	if got, want := state.Selected, 1; got != want {
+		t.Errorf("Selected = %v; want %v", got, want)
+	}
+
+	if got, want := NewState(0, 5, StyleFixPage).Height, 1; got != want {
+		t.Errorf("NewState(0, 5).Height = %v; want %v", got, want)
+	}
+}
+
+func TestReduceGroups(t *testing.T) {
+	sizes := []int{2, 2, 2, 2, 2}
+	group := func(i int) string { return customers(sizes...)(i) }
+
+	tests := []struct {
+		action Action
+		sizes  []int
+	}{
+		{ActAppend(2), []int{2, 2, 2, 2, 2, 2}},
+		{ActInsert(4, 3), []int{2, 2, 3, 2, 2, 2}},
+		{ActDelete(2, 2), []int{2, 2, 2, 2}},
+		{ActDelete(3, 2), []int{2, 1, 1, 2, 2}},
+		{ActSetLen(7), []int{2, 2, 2, 1}},
+	}
+
+	for _, test := range tests {
+		sizes = []int{2, 2, 2, 2, 2}
+		pg := New(3, 10, Groups(group), PreSelect(5))
+		state, _ := Reduce(pg.State(), test.action)
+
+		sizes = test.sizes
+		pg.Dispatch(test.action)
+
+		if got, want := state, pg.State(); !reflect.DeepEqual(got, want) {
+			t.Errorf("Reduce(%v) = %+v; want %+v", test.action, got, want)
+		}
+	}
+}
+
+func TestReduceReplay(t *testing.T) {
+	actions := []Action{ActPageDown(), ActNext(), ActPageDown(), ActPrev(), ActPageUp(), ActLast(), ActSelect(2)}
+
+	pg := New(3, len(data), Bottom())
+	state := NewState(3, len(data), StyleBottom)
+
+	var history []State
+
+	for _, a := range actions {
+		pg.Dispatch(a)
+		state, _ = Reduce(state, a)
+		history = append(history, state)
+
+		if got, want := pg.State(), state; !reflect.DeepEqual(got, want) {
+			t.Fatalf("after %+v: pager state = %+v; want %+v", a, got, want)
+		}
+	}
+
+	// undo by going back in history
+	if got, want := history[2].Selected, 9; got != want {
+		t.Errorf("history[2].Selected = %v; want %v", got, want)
+	}
+
+	if got, want := history, historyOf(NewState(3, len(data), StyleBottom), actions); !reflect.DeepEqual(got, want) {
+		t.Errorf("replay = %v; want %v", got, want)
+	}
+}
+
+func historyOf(s State, actions []Action) (history []State) {
+	for _, a := range actions {
+		s, _ = Reduce(s, a)
+		history = append(history, s)
+	}
+	return
+}
@@ -0,0 +1,97 @@
+package pager
+
+import (
+	"sync"
+)
+
+// Snapshot is a consistent state of a pager.
+type Snapshot struct {
+	// From, To and Selected are the values of Indexes.
+	From, To, Selected int
+
+	Len, Height int
+}
+
+// Synchronized is a Dispatcher that can be used by multiple goroutines at the same time.
+// Each method is atomic, and so are the functions that dispatch a single action,
+// like Select or Resize. Compound operations can be done via Update.
+//
+// The callback of the Stream option is called while the pager is locked,
+// so it must not call the pager synchronously.
+type Synchronized struct {
+	mu sync.Mutex
+	p  Dispatcher
+}
+
+// Synchronize returns a synchronized wrapper around the given pager.
+// The given pager must not be used directly afterwards.
+func Synchronize(p Dispatcher) *Synchronized {
+	return &Synchronized{p: p}
+}
+
+// Update calls fn with the wrapped pager, while the pager is locked.
+// fn must not call methods of s.
+func (s *Synchronized) Update(fn func(Dispatcher)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fn(s.p)
+}
+
+// Snapshot returns the current state.
+func (s *Synchronized) Snapshot() (snap Snapshot) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	snap.From, snap.To, snap.Selected = s.p.Indexes()
+	state := s.p.State()
+	snap.Len, snap.Height = state.Len, state.Height
+	return
+}
+
+// Next selects the next item. Returns wether the selected item has changed.
+func (s *Synchronized) Next() (changed bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.p.Next()
+}
+
+// Prev selects the previous item. Returns wether the selected item has changed.
+func (s *Synchronized) Prev() (changed bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.p.Prev()
+}
+
+// PageDown selects the next page. Returns wether the selected item has changed.
+func (s *Synchronized) PageDown() (changed bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.p.PageDown()
+}
+
+// PageUp selects the previous page. Returns wether the selected item has changed.
+func (s *Synchronized) PageUp() (changed bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.p.PageUp()
+}
+
+// Indexes returns the from, to and selected index. See Pager.
+func (s *Synchronized) Indexes() (from, to, selected int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.p.Indexes()
+}
+
+// State returns the current state.
+func (s *Synchronized) State() State {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.p.State()
+}
+
+// Dispatch applies the given action and returns its effect.
+func (s *Synchronized) Dispatch(a Action) Effect {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.p.Dispatch(a)
+}
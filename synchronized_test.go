@@ -0,0 +1,112 @@
+package pager
+
+import (
+	"sync"
+	"testing"
+)
+
+var _ Pager = &Synchronized{}
+
+func checkSnapshot(t *testing.T, snap Snapshot) {
+	t.Helper()
+
+	if snap.Len == 0 {
+		if snap.From != -1 || snap.To != -1 || snap.Selected != -1 {
+			t.Errorf("empty snapshot %+v", snap)
+		}
+		return
+	}
+
+	if snap.From < 0 || snap.Selected < 0 || snap.From+snap.Selected >= snap.To ||
+		snap.To > snap.Len || snap.To-snap.From > snap.Height {
+		t.Errorf("inconsistent snapshot %+v", snap)
+	}
+}
+
+func TestSynchronizedStress(t *testing.T) {
+	for _, style := range []Option{FixPage(), Top(), Bottom()} {
+		s := Synchronize(New(7, 1, style))
+
+		var wg sync.WaitGroup
+		const n = 2000
+
+		moves := []func() bool{s.Next, s.Prev, s.PageDown, s.PageUp}
+		for _, move := range moves {
+			wg.Add(1)
+			go func(move func() bool) {
+				defer wg.Done()
+				for i := 0; i < n; i++ {
+					move()
+				}
+			}(move)
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < n; i++ {
+				Append(s, 1)
+			}
+		}()
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < n; i++ {
+				checkSnapshot(t, s.Snapshot())
+
+				s.Update(func(p Dispatcher) {
+					p.Next()
+					p.Prev()
+				})
+
+				for _, row := range s.State().Rows() {
+					if row.Pos < 0 || row.Pos >= 7 {
+						t.Errorf("invalid row %+v", row)
+					}
+				}
+			}
+		}()
+
+		wg.Wait()
+
+		if got, want := s.State().Len, n+1; got != want {
+			t.Errorf("Len = %v; want %v", got, want)
+		}
+		checkSnapshot(t, s.Snapshot())
+	}
+}
+
+func TestSynchronizedStream(t *testing.T) {
+	var s *Synchronized
+	var wg sync.WaitGroup
+
+	s = Synchronize(New(3, 3, Stream(func(loaded int) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if loaded >= 9 {
+				Complete(s)
+				return
+			}
+			Append(s, 3)
+		}()
+	})))
+
+	for i := 0; i < 20; i++ {
+		s.PageDown()
+		wg.Wait()
+	}
+
+	if got, want := s.State().Len, 9; got != want {
+		t.Errorf("Len = %v; want %v", got, want)
+	}
+
+	if s.State().HasMore() {
+		t.Errorf("HasMore() = true; want false")
+	}
+
+	if got, want := s.Snapshot(), (Snapshot{From: 6, To: 9, Selected: 2, Len: 9, Height: 3}); got != want {
+		t.Errorf("Snapshot() = %+v; want %+v", got, want)
+	}
+}
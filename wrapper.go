@@ -0,0 +1,33 @@
+package pager
+
+// wrapper is a Dispatcher that routes all changes through its dispatch function,
+// so that wrapping pagers only have to intercept Dispatch.
+type wrapper struct {
+	Dispatcher
+	dispatch func(Action) Effect
+}
+
+// Dispatch applies the given action and returns its effect.
+func (w wrapper) Dispatch(a Action) Effect {
+	return w.dispatch(a)
+}
+
+// Next selects the next item. Returns wether the selected item has changed.
+func (w wrapper) Next() (changed bool) {
+	return w.dispatch(ActNext()).Changed()
+}
+
+// Prev selects the previous item. Returns wether the selected item has changed.
+func (w wrapper) Prev() (changed bool) {
+	return w.dispatch(ActPrev()).Changed()
+}
+
+// PageDown selects the next page. Returns wether the selected item has changed.
+func (w wrapper) PageDown() (changed bool) {
+	return w.dispatch(ActPageDown()).Changed()
+}
+
+// PageUp selects the previous page. Returns wether the selected item has changed.
+func (w wrapper) PageUp() (changed bool) {
+	return w.dispatch(ActPageUp()).Changed()
+}